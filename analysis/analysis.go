@@ -0,0 +1,201 @@
+// Package analysis compares the decisions made in a game with the optimal
+// strategy of the solver.
+package analysis
+
+import (
+	"errors"
+	"math"
+
+	"github.com/akarasz/yahtzee"
+	"github.com/akarasz/yahtzee/solver"
+)
+
+// ErrInvalidMoves is returned when the moves don't make up the turns of the
+// game.
+var ErrInvalidMoves = errors.New("invalid moves")
+
+// Analysis has the decisions of every player of a game.
+type Analysis struct {
+	Players []*Player
+}
+
+// Player has the decisions of a player in every turn. Lost and Luck are the
+// sums of the turns.
+type Player struct {
+	User yahtzee.User
+
+	// Expected is the expected points of the optimal strategy before the
+	// first turn of the player
+	Expected float64
+
+	// Lost is the expected points lost by the decisions of the player
+	Lost float64
+
+	// Luck is how many points the rolls made over what was expected of them
+	Luck float64
+
+	// Skill is 100 for the optimal play, and less the more expected points
+	// the player lost
+	Skill float64
+
+	Turns []*Turn
+}
+
+// Turn has the decisions of a turn.
+type Turn struct {
+	Round int
+
+	// Holds has the dices kept before every roll but the first one
+	Holds []*Hold
+
+	// Dices are the scored dices
+	Dices []int
+
+	// Category is where the player scored the dices, and Best is where the
+	// optimal strategy would score them
+	Category yahtzee.Category
+	Best     yahtzee.Category
+
+	// Lost is the expected points lost in the turn. Scoring before the last
+	// roll loses the points the rolls left were expected to make too.
+	Lost float64
+
+	// Luck is the difference of the expected points after and before the
+	// rolls of the turn
+	Luck float64
+}
+
+// Hold is a decision on the dices to keep for the next roll.
+type Hold struct {
+	// Dices are the values of the dices before the hold
+	Dices []int
+
+	// Kept and Best have the indices of the dices kept by the player and by
+	// the optimal strategy
+	Kept []int
+	Best []int
+
+	// Lost is the expected points lost by the hold
+	Lost float64
+}
+
+// Analyze compares the moves of the game with the optimal strategy of the
+// solver.
+func Analyze(g *yahtzee.Game, moves []yahtzee.Move, s *solver.Solver) (*Analysis, error) {
+	res := &Analysis{
+		Players: []*Player{},
+	}
+	for _, p := range g.Players {
+		player, err := analyzePlayer(g, p.User, moves, s)
+		if err != nil {
+			return nil, err
+		}
+		res.Players = append(res.Players, player)
+	}
+	return res, nil
+}
+
+func analyzePlayer(g *yahtzee.Game, u yahtzee.User, moves []yahtzee.Move, s *solver.Solver) (*Player, error) {
+	res := &Player{
+		User:  u,
+		Skill: 100,
+		Turns: []*Turn{},
+	}
+
+	rolls := []yahtzee.Move{}
+	for _, m := range moves {
+		if m.User != u {
+			continue
+		}
+		if !m.IsScore() {
+			if m.RollCount == 1 {
+				rolls = rolls[:0]
+			}
+			rolls = append(rolls, m)
+			continue
+		}
+
+		st := s.Turn(m.Sheet)
+		if len(res.Turns) == 0 {
+			res.Expected = st.Value()
+		}
+		t, err := analyzeTurn(st, g.RollsPerTurn(), rolls, m)
+		if err != nil {
+			return nil, err
+		}
+		res.Turns = append(res.Turns, t)
+		res.Lost += t.Lost
+		res.Luck += t.Luck
+		rolls = rolls[:0]
+	}
+
+	if res.Expected > 0 {
+		res.Skill = 100 * math.Max(0, 1-res.Lost/res.Expected)
+	}
+	return res, nil
+}
+
+// analyzeTurn follows the expected points of the rest of the game through the
+// rolls of the turn: the rolls change it by luck and the decisions of the
+// player by the points lost.
+func analyzeTurn(st *solver.Turn, rollsPerTurn int, rolls []yahtzee.Move, score yahtzee.Move) (*Turn, error) {
+	if len(rolls) == 0 {
+		return nil, ErrInvalidMoves
+	}
+
+	res := &Turn{
+		Round:    score.Round,
+		Holds:    []*Hold{},
+		Dices:    score.Dices,
+		Category: score.Category,
+	}
+
+	current := st.Value()
+	for i, r := range rolls {
+		if i > 0 {
+			prev := rolls[i-1]
+			left := rollsPerTurn - prev.RollCount
+			best, value, err := st.Hold(prev.Dices, left)
+			if err != nil {
+				return nil, err
+			}
+			kept, err := st.HoldValue(prev.Dices, r.Kept, left)
+			if err != nil {
+				return nil, err
+			}
+
+			lost := math.Max(0, value-kept)
+			res.Holds = append(res.Holds, &Hold{
+				Dices: prev.Dices,
+				Kept:  r.Kept,
+				Best:  best,
+				Lost:  lost,
+			})
+			res.Lost += lost
+			current = kept
+		}
+
+		value, err := st.DicesValue(r.Dices, rollsPerTurn-r.RollCount)
+		if err != nil {
+			return nil, err
+		}
+		res.Luck += value - current
+		current = value
+	}
+
+	values, err := st.Categories(score.Dices)
+	if err != nil {
+		return nil, err
+	}
+	scored, ok := values[score.Category]
+	if !ok {
+		return nil, ErrInvalidMoves
+	}
+	res.Best, _, err = st.Category(score.Dices)
+	if err != nil {
+		return nil, err
+	}
+	res.Lost += math.Max(0, current-scored)
+
+	return res, nil
+}
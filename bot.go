@@ -0,0 +1,100 @@
+package yahtzee
+
+import "errors"
+
+var (
+	// ErrInvalidDifficulty is returned when a bot is added with an unknown
+	// difficulty.
+	ErrInvalidDifficulty = errors.New("invalid difficulty")
+
+	// ErrNotCreator is returned when somebody else than the creator of the
+	// game adds a bot.
+	ErrNotCreator = errors.New("not the creator of the game")
+)
+
+// Difficulty tells how well a bot plays.
+type Difficulty string
+
+// Available difficulties
+const (
+	// Greedy keeps the dices of the most frequent value and scores the most
+	// points
+	Greedy Difficulty = "greedy"
+
+	// Smart plays the turn for the best expected points
+	Smart Difficulty = "smart"
+
+	// Optimal plays for the best expected points of the whole game
+	Optimal Difficulty = "optimal"
+)
+
+// Difficulties returns the difficulties from the easiest.
+func Difficulties() []Difficulty {
+	return []Difficulty{Greedy, Smart, Optimal}
+}
+
+// AddBot joins a bot player with the difficulty to the game. In the Teams mode
+// the bot joins the team.
+func (g *Game) AddBot(u User, d Difficulty, team string) error {
+	valid := false
+	for _, difficulty := range Difficulties() {
+		if d == difficulty {
+			valid = true
+		}
+	}
+	if !valid {
+		return ErrInvalidDifficulty
+	}
+
+	if err := g.AddPlayerToTeam(u, team); err != nil {
+		return err
+	}
+	g.Player(u).Bot = d
+	return nil
+}
+
+// Start starts the game before anybody acted in it, so nobody can join it
+// anymore and the bots can take their turns.
+func (g *Game) Start() error {
+	if len(g.Players) == 0 {
+		return ErrNoPlayers
+	}
+	if g.started() {
+		return ErrAlreadyStarted
+	}
+	g.Started = true
+	return nil
+}
+
+// NextBot returns the bot player who has to act in the game, or nil when it's
+// a human's turn. The bots wait until the game is started or a human player
+// acts in it, so everybody can join before the bots play.
+func (g *Game) NextBot() *Player {
+	if g.IsOver() || len(g.Players) == 0 || !g.started() {
+		return nil
+	}
+
+	humans := false
+	for _, p := range g.Players {
+		if p.Bot == "" {
+			humans = true
+		}
+	}
+	if !humans {
+		return nil
+	}
+
+	if g.HasFeature(Simultaneous) {
+		for _, p := range g.Players {
+			if p.Bot != "" && !p.Scored {
+				return p
+			}
+		}
+		return nil
+	}
+
+	if p := g.Players[g.CurrentPlayer]; p.Bot != "" {
+		return p
+	}
+	return nil
+}
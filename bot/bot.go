@@ -0,0 +1,153 @@
+// Package bot decides the moves of the bot players.
+package bot
+
+import (
+	"errors"
+
+	"github.com/akarasz/yahtzee"
+	"github.com/akarasz/yahtzee/solver"
+)
+
+// ErrNoMove is returned when the bot can't score its dices anywhere.
+var ErrNoMove = errors.New("no move for the bot")
+
+// Kind tells what a move does.
+type Kind string
+
+// Available kinds
+const (
+	Roll  Kind = "roll"
+	Lock  Kind = "lock"
+	Score Kind = "score"
+)
+
+// Move is the next action of a bot.
+type Move struct {
+	Kind Kind
+
+	// Dice is the index of the dice to lock or unlock
+	Dice int
+
+	// Column is the score sheet column to score into
+	Column int
+
+	// Category is the category to score into
+	Category yahtzee.Category
+}
+
+// strategy decides the plays of a difficulty.
+type strategy interface {
+	// hold returns the indices of the dices to keep for the next roll
+	hold(g *yahtzee.Game) ([]int, error)
+
+	// score returns the column and the category to score the dices into
+	score(g *yahtzee.Game) (int, yahtzee.Category, error)
+}
+
+// Next returns the next move of the bot player. The bot rolls first, then
+// locks the dices it keeps one by one and rolls again while it has rolls
+// left, and scores when it keeps every dice or has no more rolls.
+func Next(g *yahtzee.Game, p *yahtzee.Player) (*Move, error) {
+	restore, err := g.ViewAs(p.User)
+	if err != nil {
+		return nil, err
+	}
+	defer restore()
+
+	column := g.Column
+	defer func() { g.Column = column }()
+
+	if g.RollCount == 0 {
+		return &Move{Kind: Roll}, nil
+	}
+
+	s, err := strategyOf(g, p.Bot)
+	if errors.Is(err, solver.ErrNoTable) {
+		// the solver of an optimal bot is gone, eg. after a restart without
+		// its table
+		s, err = smart{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if g.RollsLeft() > 0 {
+		hold, err := s.hold(g)
+		if errors.Is(err, yahtzee.ErrTooManyCombinations) {
+			hold, err = greedy{}.hold(g)
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		keep := make([]bool, len(g.Dices))
+		for _, i := range hold {
+			keep[i] = true
+		}
+		for i, d := range g.Dices {
+			if d.Locked != keep[i] {
+				return &Move{Kind: Lock, Dice: i}, nil
+			}
+		}
+		if len(hold) < len(g.Dices) {
+			return &Move{Kind: Roll}, nil
+		}
+	}
+
+	c, category, err := s.score(g)
+	if errors.Is(err, yahtzee.ErrTooManyCombinations) {
+		c, category, err = greedy{}.score(g)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &Move{
+		Kind:     Score,
+		Column:   c,
+		Category: category,
+	}, nil
+}
+
+// Supports checks if a bot with the difficulty can play the game. The optimal
+// bots need a registered solver, as solving a game takes too long to wait for.
+func Supports(g *yahtzee.Game, d yahtzee.Difficulty) error {
+	_, err := strategyOf(g, d)
+	return err
+}
+
+func strategyOf(g *yahtzee.Game, d yahtzee.Difficulty) (strategy, error) {
+	switch d {
+	case yahtzee.Greedy:
+		return greedy{}, nil
+	case yahtzee.Smart:
+		return smart{}, nil
+	case yahtzee.Optimal:
+		s, err := solver.For(g)
+		if err != nil {
+			return nil, err
+		}
+		return &optimal{s}, nil
+	default:
+		return nil, yahtzee.ErrInvalidDifficulty
+	}
+}
+
+// openCategories returns the categories the dices can be scored in the column
+// of the game.
+func openCategories(g *yahtzee.Game) []yahtzee.Category {
+	res := []yahtzee.Category{}
+	for _, c := range g.OpenCategories() {
+		if g.CheckScoreRules(c) == nil {
+			res = append(res, c)
+		}
+	}
+	return res
+}
+
+func values(dices []*yahtzee.Dice) []int {
+	res := make([]int, len(dices))
+	for i, d := range dices {
+		res[i] = d.Value
+	}
+	return res
+}
@@ -0,0 +1,109 @@
+package bot
+
+import (
+	"math"
+
+	"github.com/akarasz/yahtzee"
+	"github.com/akarasz/yahtzee/solver"
+)
+
+// greedy keeps the dices of the most frequent value, and scores the most
+// points.
+type greedy struct{}
+
+func (greedy) hold(g *yahtzee.Game) ([]int, error) {
+	counts := map[int]int{}
+	best := 0
+	for _, d := range g.Dices {
+		counts[d.Value]++
+		if counts[d.Value] > counts[best] || (counts[d.Value] == counts[best] && d.Value > best) {
+			best = d.Value
+		}
+	}
+
+	res := []int{}
+	for i, d := range g.Dices {
+		if d.Value == best {
+			res = append(res, i)
+		}
+	}
+	return res, nil
+}
+
+func (greedy) score(g *yahtzee.Game) (int, yahtzee.Category, error) {
+	return bestOf(g, func(c yahtzee.Category) (float64, error) {
+		return float64(g.Scorer.ScoreActions[c](g)), nil
+	})
+}
+
+// smart plays the turn for the best expected points of the categories.
+type smart struct{}
+
+func (smart) hold(g *yahtzee.Game) ([]int, error) {
+	var res []int
+	best := math.Inf(-1)
+	for column := 0; column < g.NumberOfColumns(); column++ {
+		g.Column = column
+		e, err := g.Expect()
+		if err != nil {
+			return nil, err
+		}
+		if e.Value > best {
+			res, best = e.Hold, e.Value
+		}
+	}
+	return res, nil
+}
+
+func (smart) score(g *yahtzee.Game) (int, yahtzee.Category, error) {
+	var e *yahtzee.Expectation
+	column := -1
+	return bestOf(g, func(c yahtzee.Category) (float64, error) {
+		if column != g.Column {
+			var err error
+			if e, err = g.Expect(); err != nil {
+				return 0, err
+			}
+			column = g.Column
+		}
+		return e.Expected[c], nil
+	})
+}
+
+// optimal plays for the best expected points of the game with the solver.
+type optimal struct {
+	solver *solver.Solver
+}
+
+func (o *optimal) hold(g *yahtzee.Game) ([]int, error) {
+	hold, _, err := o.solver.Turn(g.CurrentScoreSheet()).Hold(values(g.Dices), g.RollsLeft())
+	return hold, err
+}
+
+func (o *optimal) score(g *yahtzee.Game) (int, yahtzee.Category, error) {
+	c, _, err := o.solver.Turn(g.CurrentScoreSheet()).Category(values(g.Dices))
+	return 0, c, err
+}
+
+// bestOf returns the column and the open category with the best value.
+func bestOf(g *yahtzee.Game, value func(c yahtzee.Category) (float64, error)) (int, yahtzee.Category, error) {
+	var res yahtzee.Category
+	column := 0
+	best := math.Inf(-1)
+	for col := 0; col < g.NumberOfColumns(); col++ {
+		g.Column = col
+		for _, c := range openCategories(g) {
+			v, err := value(c)
+			if err != nil {
+				return 0, "", err
+			}
+			if v > best {
+				res, column, best = c, col, v
+			}
+		}
+	}
+	if res == "" {
+		return 0, "", ErrNoMove
+	}
+	return column, res, nil
+}
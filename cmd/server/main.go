@@ -5,6 +5,7 @@ import (
 	"math/rand"
 	"net/http"
 	"os"
+	"path/filepath"
 	"time"
 
 	"github.com/go-redis/redis/v8"
@@ -13,6 +14,7 @@ import (
 
 	event "github.com/akarasz/yahtzee/event/rabbit"
 	"github.com/akarasz/yahtzee/handler"
+	"github.com/akarasz/yahtzee/solver"
 	store "github.com/akarasz/yahtzee/store/redis"
 )
 
@@ -42,6 +44,11 @@ func main() {
 		panic(err)
 	}
 
+	// solver tables for the optimal bots
+	if dir := os.Getenv("SOLVER_TABLES"); dir != "" {
+		loadSolvers(dir)
+	}
+
 	go func() {
 		http.Handle("/metrics", promhttp.Handler())
 		http.ListenAndServe(":2112", nil)
@@ -55,3 +62,23 @@ func main() {
 	listenAddress := ":" + port
 	log.Fatal(http.ListenAndServe(listenAddress, handler.New(s, e, e)))
 }
+
+func loadSolvers(dir string) {
+	files, err := filepath.Glob(filepath.Join(dir, "*.table"))
+	if err != nil {
+		panic(err)
+	}
+	for _, name := range files {
+		f, err := os.Open(name)
+		if err != nil {
+			panic(err)
+		}
+		s, err := solver.Read(f)
+		f.Close()
+		if err != nil {
+			log.Printf("solver table %s: %v", name, err)
+			continue
+		}
+		solver.Register(s)
+	}
+}
@@ -0,0 +1,43 @@
+package main
+
+import (
+	"flag"
+	"log"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/akarasz/yahtzee"
+	"github.com/akarasz/yahtzee/solver"
+)
+
+func main() {
+	features := flag.String("features", "", "comma separated features of the game")
+	out := flag.String("out", "solver.table", "file to save the table to")
+	flag.Parse()
+
+	fs := []yahtzee.Feature{}
+	for _, f := range strings.Split(*features, ",") {
+		if f != "" {
+			fs = append(fs, yahtzee.Feature(f))
+		}
+	}
+
+	s, err := solver.New(yahtzee.GameOptions{}, fs...)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	start := time.Now()
+	value := s.Solve()
+	log.Printf("expected points %.2f, %d states in %s", value, s.Len(), time.Since(start))
+
+	f, err := os.Create(*out)
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer f.Close()
+	if err := s.Save(f); err != nil {
+		log.Fatal(err)
+	}
+}
@@ -16,9 +16,15 @@ type Type string
 // Available types
 const (
 	AddPlayer Type = "add-player"
+	Start     Type = "start"
 	Roll      Type = "roll"
 	Lock      Type = "lock"
 	Score     Type = "score"
+
+	RequestUndo Type = "request-undo"
+	ApproveUndo Type = "approve-undo"
+	DeclineUndo Type = "decline-undo"
+	Undo        Type = "undo"
 )
 
 // Subscriber for subscribe events
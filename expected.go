@@ -0,0 +1,279 @@
+package yahtzee
+
+import (
+	"errors"
+	"math"
+
+	"github.com/akarasz/yahtzee/internal/dice"
+)
+
+// MaxCombinations is the most dice combinations the expected values are
+// calculated for.
+const MaxCombinations = 500000
+
+// ErrTooManyCombinations is returned when the expected values would take too
+// long to calculate for the dices of the game.
+var ErrTooManyCombinations = errors.New("too many dice combinations")
+
+// Expectation has the expected values of the current turn.
+type Expectation struct {
+	// Expected has the expected points of each open category when the player
+	// plays the remaining rolls for it, with its share of the upper section
+	// bonus
+	Expected map[Category]float64
+
+	// Hold has the indices of the dices to lock before the next roll
+	Hold []int
+
+	// Value is the expected points of the turn when the dices in Hold are kept
+	// and the remaining rolls are played the best way
+	Value float64
+}
+
+// Expect returns the expected values of the current player's turn with the
+// remaining rolls. The upper section categories are worth their share of the
+// bonus too while the bonus can be reached. The player can score after any
+// roll, so the dices are worth what the rules give for them at that roll.
+func (g *Game) Expect() (*Expectation, error) {
+	if len(g.Players) == 0 {
+		return nil, ErrNoPlayers
+	}
+	if g.IsOver() {
+		return nil, ErrGameOver
+	}
+
+	n, faces := len(g.Dices), g.Faces()
+	if dice.Count(n, faces, MaxCombinations) > MaxCombinations {
+		return nil, ErrTooManyCombinations
+	}
+
+	e := newExpecter(g, n, faces)
+
+	res := &Expectation{
+		Expected: map[Category]float64{},
+		Hold:     []int{},
+	}
+
+	rolls := g.RollsLeft()
+	current := e.Index[e.Key(countsOf(g.Dices, faces))]
+	var value, now []float64
+	switch {
+	case rolls == 0:
+		value = e.scoresAt(g.RollCount)[current]
+	case g.RollCount == 0:
+		value = e.holdValues(rolls - 1)[e.EmptyHold]
+	default:
+		var best int
+		value, best = e.best(current, e.holdValues(rolls-1))
+		res.Hold = holdIndices(g.Dices, e.Holds[best])
+		now = e.scoresAt(g.RollCount)[current]
+	}
+
+	for t, c := range e.targets {
+		res.Expected[c] = value[t]
+		if now != nil {
+			res.Expected[c] = math.Max(value[t], now[t])
+		}
+	}
+	res.Value = value[len(e.targets)]
+
+	return res, nil
+}
+
+// expecter calculates the expected values of a turn over the combinations of
+// the dices. A combination is the count of the dices of each value.
+type expecter struct {
+	*dice.Combinations
+
+	game *Game
+
+	// targets are the open categories; the last value of every value vector
+	// is the best of them
+	targets []Category
+
+	// final is the roll count of the turn after the last roll
+	final int
+
+	// scores has the value vectors of every combination by the roll count
+	// they are scored at
+	scores map[int][][]float64
+}
+
+func newExpecter(g *Game, n, faces int) *expecter {
+	e := &expecter{
+		Combinations: dice.New(n, faces),
+		game:         g,
+		final:        g.RollCount + g.RollsLeft(),
+		scores:       map[int][][]float64{},
+	}
+
+	e.targets = g.OpenCategories()
+
+	return e
+}
+
+// scoresAt returns the value vectors of scoring every combination after the
+// `rollCount`th roll of the turn.
+func (e *expecter) scoresAt(rollCount int) [][]float64 {
+	if res, ok := e.scores[rollCount]; ok {
+		return res
+	}
+
+	g := e.game
+	defer func(rollCount int) { g.RollCount = rollCount }(g.RollCount)
+	g.RollCount = rollCount
+
+	res := make([][]float64, len(e.States))
+	for i, s := range e.States {
+		res[i] = e.values(s)
+	}
+	e.scores[rollCount] = res
+	return res
+}
+
+// values returns the value of scoring the dices in each target, and the best
+// of them. The categories the rules don't allow for the dices are worth
+// nothing.
+func (e *expecter) values(s dice.Counts) []float64 {
+	g := e.game
+	dices := g.Dices
+	defer func() { g.Dices = dices }()
+
+	g.Dices = []*Dice{}
+	for v, c := range s {
+		for i := 0; i < c; i++ {
+			g.Dices = append(g.Dices, &Dice{Value: v + 1})
+		}
+	}
+
+	res := make([]float64, len(e.targets)+1)
+	res[len(e.targets)] = math.Inf(-1)
+	for t, c := range e.targets {
+		if g.CheckScoreRules(c) != nil {
+			res[t] = 0
+			continue
+		}
+		points := g.Scorer.ScoreActions[c](g)
+		res[t] = float64(points) + g.upperBonusShare(c, points)
+		res[len(e.targets)] = math.Max(res[len(e.targets)], res[t])
+	}
+	if len(e.targets) == 0 {
+		res[0] = 0
+	}
+	return res
+}
+
+// levels returns the value vectors of every state when `rolls` rolls are left.
+// The player either scores the state or keeps the best hold for the next roll.
+func (e *expecter) levels(rolls int) [][]float64 {
+	scores := e.scoresAt(e.final - rolls)
+	if rolls == 0 {
+		return scores
+	}
+	holds := e.holdValues(rolls - 1)
+	res := make([][]float64, len(e.States))
+	for i := range e.States {
+		res[i], _ = e.best(i, holds)
+		for t := range res[i] {
+			res[i][t] = math.Max(res[i][t], scores[i][t])
+		}
+	}
+	return res
+}
+
+// best returns the best value of each target over the holds that can be kept
+// from the state, and the hold with the best value of all targets.
+func (e *expecter) best(state int, holds [][]float64) ([]float64, int) {
+	all := len(e.targets)
+	res := make([]float64, all+1)
+	for t := range res {
+		res[t] = math.Inf(-1)
+	}
+	best := e.SubHolds[state][0]
+	for _, h := range e.SubHolds[state] {
+		for t := range res {
+			res[t] = math.Max(res[t], holds[h][t])
+		}
+		if holds[h][all] > holds[best][all] {
+			best = h
+		}
+	}
+	return res, best
+}
+
+// holdValues returns the expected value vectors of rolling the dices beside
+// each hold, when `rolls` rolls are left after that roll.
+func (e *expecter) holdValues(rolls int) [][]float64 {
+	values := e.levels(rolls)
+	res := make([][]float64, len(e.Holds))
+	for i := range e.Holds {
+		res[i] = make([]float64, len(e.targets)+1)
+		for _, o := range e.Outcomes[i] {
+			for t := range res[i] {
+				res[i][t] += o.Probability * values[o.State][t]
+			}
+		}
+	}
+	return res
+}
+
+// upperBonusShare returns the part of the upper section bonus the points are
+// worth in the category: the whole bonus when they reach the threshold, a
+// share proportional to the threshold while the bonus can still be reached,
+// and nothing otherwise.
+func (g *Game) upperBonusShare(c Category, points int) float64 {
+	ruleset := g.Ruleset()
+	sheet := g.CurrentScoreSheet()
+	if ruleset.UpperSectionBonus == 0 || ruleset.UpperSectionThreshold == 0 {
+		return 0
+	}
+	if _, ok := sheet[Bonus]; ok {
+		return 0
+	}
+
+	upper := UpperCategories(ruleset.Faces)
+	if !containsCategory(upper, c) {
+		return 0
+	}
+
+	total, reachable := 0, 0
+	for i, u := range upper {
+		if v, ok := sheet[u]; ok {
+			total += v
+		} else if u != c {
+			reachable += (i + 1) * len(g.Dices)
+		}
+	}
+
+	switch {
+	case total+points >= ruleset.UpperSectionThreshold:
+		return float64(ruleset.UpperSectionBonus)
+	case total+points+reachable < ruleset.UpperSectionThreshold:
+		return 0
+	default:
+		return float64(ruleset.UpperSectionBonus*points) / float64(ruleset.UpperSectionThreshold)
+	}
+}
+
+// holdIndices returns the indices of the dices to lock for keeping the hold.
+func holdIndices(dices []*Dice, hold dice.Counts) []int {
+	left := append(dice.Counts{}, hold...)
+	res := []int{}
+	for i, d := range dices {
+		if left[d.Value-1] > 0 {
+			left[d.Value-1]--
+			res = append(res, i)
+		}
+	}
+	return res
+}
+
+func countsOf(dices []*Dice, faces int) dice.Counts {
+	res := make(dice.Counts, faces)
+	for _, d := range dices {
+		if d.Value >= 1 && d.Value <= faces {
+			res[d.Value-1]++
+		}
+	}
+	return res
+}
@@ -0,0 +1,359 @@
+package yahtzee
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+)
+
+var (
+	// ErrFeatureRegistered is returned when a feature is registered with a name
+	// that is already taken.
+	ErrFeatureRegistered = errors.New("feature already registered")
+
+	// ErrInvalidFeature is returned when a feature can't be registered.
+	ErrInvalidFeature = errors.New("invalid feature")
+
+	// ErrUnknownFeature is returned when a game is set up with a feature that
+	// is not registered.
+	ErrUnknownFeature = errors.New("unknown feature")
+
+	// ErrConflictingFeatures is returned when a game is set up with features
+	// that can't be played together.
+	ErrConflictingFeatures = errors.New("conflicting features")
+)
+
+// FeatureDescriptor describes how a feature changes the game.
+type FeatureDescriptor struct {
+	// DisplayName is the name of the feature shown to the players
+	DisplayName string
+
+	// Description explains the rules of the feature to the players
+	Description string
+
+	// Ruleset returns the score sheet of the games played with the feature.
+	// The ruleset of the other features or the default one is used when nil.
+	Ruleset func() *Ruleset
+
+	// ScoreRules reject the categories that can't be scored with the current
+	// dices
+	ScoreRules []func(game *Game, c Category) error
+
+	// PreScoreActions run before a category is scored
+	PreScoreActions []func(game *Game)
+
+	// ScoreActions replace the scorers of the ruleset's categories
+	ScoreActions Scorers
+
+	// PostScoreActions run after a category is scored
+	PostScoreActions []func(game *Game)
+
+	// PostGameActions run when the game is over
+	PostGameActions []func(game *Game)
+
+	// Conflicts has the features the game can't be played together with
+	Conflicts []Feature
+
+	// Implies has the features the game is played with too when it's played
+	// with this feature
+	Implies []Feature
+
+	// After has the features which actions and scorers are applied before the
+	// ones of this feature when the game is played with both of them
+	After []Feature
+}
+
+var registry = struct {
+	sync.RWMutex
+	names       []Feature
+	descriptors map[Feature]FeatureDescriptor
+}{
+	names:       []Feature{},
+	descriptors: map[Feature]FeatureDescriptor{},
+}
+
+// RegisterFeature makes the feature available for the games. It's usually
+// called from an init function; the features are listed in the order of the
+// registration.
+func RegisterFeature(name Feature, descriptor FeatureDescriptor) error {
+	if name == "" {
+		return ErrInvalidFeature
+	}
+
+	registry.Lock()
+	defer registry.Unlock()
+
+	if _, ok := registry.descriptors[name]; ok {
+		return ErrFeatureRegistered
+	}
+	registry.names = append(registry.names, name)
+	registry.descriptors[name] = descriptor
+
+	return nil
+}
+
+// LookupFeature returns the descriptor of a registered feature.
+func LookupFeature(name Feature) (FeatureDescriptor, bool) {
+	registry.RLock()
+	defer registry.RUnlock()
+
+	d, ok := registry.descriptors[name]
+	return d, ok
+}
+
+// Features returns the registered features.
+func Features() []Feature {
+	registry.RLock()
+	defer registry.RUnlock()
+
+	res := make([]Feature, len(registry.names))
+	copy(res, registry.names)
+	return res
+}
+
+// FeatureMetadata describes a registered feature for the players.
+type FeatureMetadata struct {
+	// ID is the name the feature is referred with
+	ID Feature
+
+	// DisplayName is the name of the feature shown to the players
+	DisplayName string
+
+	// Description explains the rules of the feature
+	Description string
+
+	// Conflicts has the features the feature can't be played together with
+	Conflicts []Feature
+
+	// Implies has the features that are added to the game with the feature
+	Implies []Feature
+}
+
+// DescribeFeatures returns the metadata of the registered features. The
+// conflicts declared by any of the two features are listed at both of them.
+func DescribeFeatures() []FeatureMetadata {
+	features := Features()
+	res := make([]FeatureMetadata, len(features))
+	for i, f := range features {
+		d, _ := LookupFeature(f)
+		res[i] = FeatureMetadata{
+			ID:          f,
+			DisplayName: d.DisplayName,
+			Description: d.Description,
+			Conflicts:   []Feature{},
+			Implies:     []Feature{},
+		}
+		for _, other := range features {
+			if other != f && conflicting(f, other) {
+				res[i].Conflicts = append(res[i].Conflicts, other)
+			}
+		}
+		res[i].Implies = append(res[i].Implies, d.Implies...)
+	}
+	return res
+}
+
+// ResolveFeatures checks that the features are registered and can be played
+// together, and returns them without duplicates and with the features they
+// imply.
+func ResolveFeatures(features ...Feature) ([]Feature, error) {
+	res := []Feature{}
+	var add func(f Feature) error
+	add = func(f Feature) error {
+		if ContainsFeature(res, f) {
+			return nil
+		}
+		d, ok := LookupFeature(f)
+		if !ok {
+			return fmt.Errorf("%w: %q", ErrUnknownFeature, f)
+		}
+		res = append(res, f)
+		for _, implied := range d.Implies {
+			if err := add(implied); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	for _, f := range features {
+		if err := add(f); err != nil {
+			return nil, err
+		}
+	}
+
+	for i, a := range res {
+		for _, b := range res[i+1:] {
+			if conflicting(a, b) {
+				return nil, fmt.Errorf("%w: %q can't be played with %q", ErrConflictingFeatures, a, b)
+			}
+		}
+	}
+
+	return res, nil
+}
+
+// conflicting tells if any of the two features declared a conflict with the
+// other.
+func conflicting(a, b Feature) bool {
+	da, _ := LookupFeature(a)
+	db, _ := LookupFeature(b)
+	return ContainsFeature(da.Conflicts, b) || ContainsFeature(db.Conflicts, a)
+}
+
+// orderFeatures returns the registered ones of the features in the order
+// their actions are applied: the order of the registration, except that a
+// feature always comes after the features in its After list.
+func orderFeatures(features []Feature) []Feature {
+	remaining := []Feature{}
+	for _, f := range Features() {
+		if ContainsFeature(features, f) {
+			remaining = append(remaining, f)
+		}
+	}
+
+	res := []Feature{}
+	for len(remaining) > 0 {
+		next := 0
+		for i, f := range remaining {
+			if isReady(f, remaining) {
+				next = i
+				break
+			}
+		}
+		res = append(res, remaining[next])
+		remaining = append(remaining[:next], remaining[next+1:]...)
+	}
+	return res
+}
+
+// isReady tells if none of the features the `feature` comes after is in the
+// `remaining` ones.
+func isReady(feature Feature, remaining []Feature) bool {
+	d, _ := LookupFeature(feature)
+	for _, f := range d.After {
+		if f != feature && ContainsFeature(remaining, f) {
+			return false
+		}
+	}
+	return true
+}
+
+func init() {
+	rulesets := []Feature{YachtRules, GeneralaRules, MaxiRules, PokerDice}
+	conflicts := func(ruleset Feature, others ...Feature) []Feature {
+		res := []Feature{YahtzeeBonus, Official}
+		for _, f := range rulesets {
+			if f != ruleset {
+				res = append(res, f)
+			}
+		}
+		return append(res, others...)
+	}
+
+	builtin := []struct {
+		name       Feature
+		descriptor FeatureDescriptor
+	}{
+		{SixDice, FeatureDescriptor{
+			DisplayName: "Six dice",
+			Description: "Play the game with six dice. Points are calculated with the best 5 of them!",
+		}},
+		{YahtzeeBonus, FeatureDescriptor{
+			DisplayName:      "Yahtzee bonus",
+			Description:      "The base game with the yahtzee bonus and a basic joker rule: You are eligible for the bonus 100 point if you already filled the yahtzee with 50 points. You can also use your second yahtzee to fill the full house, small straight or large straight with 25-30-40 points respectively, without any restrictions.",
+			PreScoreActions:  []func(game *Game){YahtzeeBonusPreScoreAction},
+			PostScoreActions: []func(game *Game){YahtzeeBonusPostScoreAction},
+			ScoreActions: Scorers{
+				FullHouse:     YahtzeeBonusFullHouse,
+				SmallStraight: YahtzeeBonusSmallStraight,
+				LargeStraight: YahtzeeBonusLargeStraight,
+			},
+		}},
+		{TheChance, FeatureDescriptor{
+			DisplayName:     "The Chance",
+			Description:     "This is your chance to win! Score 5 points in the entire game, and you will get a bonus 495 at the end!",
+			PostGameActions: []func(game *Game){TheChanceAction},
+		}},
+		{Equilizer, FeatureDescriptor{
+			DisplayName:      "Equilizer",
+			Description:      "Everyone can score, except you? With the equilizer, when you score a zero in a category, all other players will have zero in the same category if they already filled that category. Use it wisely!",
+			PreScoreActions:  []func(game *Game){EquilizerPreScoreAction},
+			PostScoreActions: []func(game *Game){EquilizerPostScoreAction},
+			After:            []Feature{YahtzeeBonus},
+		}},
+		{Ordered, FeatureDescriptor{
+			DisplayName: "Ordered",
+			Description: "Enforce top-down filling of the categories.",
+		}},
+		{Official, FeatureDescriptor{
+			DisplayName:      "Official",
+			Description:      "Game with the official yahtzee rules: the yahtzee bonus and the joker rules. An extra yahtzee has to be scored in the matching upper section box when it's open, in a lower section box otherwise.",
+			ScoreRules:       []func(game *Game, c Category) error{OfficialJokerRule},
+			PreScoreActions:  []func(game *Game){OfficialYahtzeeBonusPreScoreAction},
+			PostScoreActions: []func(game *Game){OfficialYahtzeeBonusPostScoreAction},
+			ScoreActions: Scorers{
+				ThreeOfAKind:  OfficialThreeOfAKind,
+				FourOfAKind:   OfficialFourOfAKind,
+				FullHouse:     OfficialFullHouse,
+				SmallStraight: OfficialSmallStraight,
+				LargeStraight: OfficialLargeStraight,
+			},
+			After: []Feature{YahtzeeBonus},
+		}},
+		{Triple, FeatureDescriptor{
+			DisplayName: "Triple",
+			Description: "Every player has three score sheet columns worth x1, x2 and x3 of their total, and every category has to be filled in each column.",
+		}},
+		{YachtRules, FeatureDescriptor{
+			DisplayName: "Yacht",
+			Description: "The classic Yacht rules with Little Straight (1-5) and Big Straight (2-6) for 30 points, Full House and Four of a Kind for the sum of the dice, Choice and Yacht for 50 points. There is no upper section bonus.",
+			Ruleset:     YachtRuleset,
+			Conflicts:   conflicts(YachtRules),
+		}},
+		{GeneralaRules, FeatureDescriptor{
+			DisplayName:      "Generala",
+			Description:      "The Latin American Generala rules with Escalera (20), Full (30), Poker (40) and Generala (50). A combination made with the first roll is served and worth 5 more points, and a served Generala wins the game immediately.",
+			Ruleset:          GeneralaRuleset,
+			PostScoreActions: []func(game *Game){GeneralaServedAction},
+			Conflicts:        conflicts(GeneralaRules),
+		}},
+		{MaxiRules, FeatureDescriptor{
+			DisplayName: "Maxi Yahtzee",
+			Description: "Played with six dice for 20 rounds with One Pair, Two Pairs, Three Pairs, Five of a Kind, Full Straight, Castle, Tower and Maxi Yahtzee besides the usual categories. The upper section bonus is 50 points from 84 points.",
+			Ruleset:     MaxiRuleset,
+			Conflicts:   conflicts(MaxiRules),
+			Implies:     []Feature{SixDice},
+		}},
+		{SavedRolls, FeatureDescriptor{
+			DisplayName: "Saved rolls",
+			Description: "Rolls left unused in a turn are saved for the later turns.",
+		}},
+		{PokerDice, FeatureDescriptor{
+			DisplayName:      "Poker Dice",
+			Description:      "Dice with 9, 10, J, Q, K and A faces. There is no score sheet: the best hand wins the round, and the most rounds won wins the game.",
+			Ruleset:          PokerDiceRuleset,
+			PostScoreActions: []func(game *Game){PokerDiceRoundAction},
+			Conflicts:        conflicts(PokerDice, SixDice, TheChance, Equilizer, Triple),
+		}},
+		{Teams, FeatureDescriptor{
+			DisplayName: "Teams",
+			Description: "Players join named teams. Teammates share a score sheet and take turns in rotation, and the team totals decide the standings.",
+			Conflicts:   []Feature{PokerDice},
+		}},
+		{Duplicate, FeatureDescriptor{
+			DisplayName: "Duplicate",
+			Description: "Every player gets the same dices in a round for the same roll, whichever dices they keep, so the scores measure the decisions instead of the luck. The standings show how far each player was from the best line of every round.",
+			Conflicts:   []Feature{PokerDice, Teams},
+		}},
+		{Simultaneous, FeatureDescriptor{
+			DisplayName: "Simultaneous turns",
+			Description: "Everyone rolls, locks and scores their own dices at the same time, and the next round starts when every player scored.",
+			Conflicts:   []Feature{PokerDice, Teams},
+		}},
+	}
+	for _, f := range builtin {
+		if err := RegisterFeature(f.name, f.descriptor); err != nil {
+			panic(err)
+		}
+	}
+}
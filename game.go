@@ -0,0 +1,317 @@
+package yahtzee
+
+import "errors"
+
+var (
+	// ErrAlreadyStarted is returned when a player tries to join a game that
+	// has been started already.
+	ErrAlreadyStarted = errors.New("game already started")
+
+	// ErrAlreadyJoined is returned when the user is already playing the game.
+	ErrAlreadyJoined = errors.New("already joined")
+
+	// ErrNotJoined is returned when an action needs a user who didn't join the
+	// game.
+	ErrNotJoined = errors.New("not joined")
+
+	// ErrNoPlayers is returned when an action needs players but nobody joined.
+	ErrNoPlayers = errors.New("no players joined")
+
+	// ErrNotYourTurn is returned when a user acts in another player's turn.
+	ErrNotYourTurn = errors.New("another players turn")
+
+	// ErrGameOver is returned when an action is made after the last round.
+	ErrGameOver = errors.New("game is over")
+
+	// ErrGameNotOver is returned when an action needs the game to be over.
+	ErrGameNotOver = errors.New("game is not over")
+
+	// ErrRollFirst is returned when the dices were not rolled in this turn yet.
+	ErrRollFirst = errors.New("roll first")
+
+	// ErrNoMoreRolls is returned when the player used all the rolls of the turn.
+	ErrNoMoreRolls = errors.New("no more rolls")
+
+	// ErrInvalidDice is returned when the dice index is out of range.
+	ErrInvalidDice = errors.New("invalid dice index")
+
+	// ErrCategoryUsed is returned when the category was scored already.
+	ErrCategoryUsed = errors.New("category is already used")
+
+	// ErrInvalidCategory is returned when the category can't be scored.
+	ErrInvalidCategory = errors.New("invalid category")
+
+	// ErrInvalidColumn is returned when the score sheet column doesn't exist.
+	ErrInvalidColumn = errors.New("invalid column")
+
+	// ErrJokerRules is returned when an extra yahtzee is scored in a category
+	// the joker rules don't allow.
+	ErrJokerRules = errors.New("category not allowed by the joker rules")
+)
+
+// IsOver tells if every round of the game was played or somebody won the game
+// before that.
+func (g *Game) IsOver() bool {
+	return g.Winner != "" || g.Round >= g.Rounds()
+}
+
+// AddPlayer joins the user to the game. In the Teams mode the players join
+// with AddPlayerToTeam.
+func (g *Game) AddPlayer(u User) error {
+	if g.HasFeature(Teams) {
+		return ErrTeamRequired
+	}
+	return g.addPlayer(u)
+}
+
+func (g *Game) addPlayer(u User) error {
+	if g.started() {
+		return ErrAlreadyStarted
+	}
+	for _, p := range g.Players {
+		if p.User == u {
+			return ErrAlreadyJoined
+		}
+	}
+
+	p := NewPlayer(u)
+	if columns := g.NumberOfColumns(); columns > 1 && !g.HasFeature(Teams) {
+		p.Columns = make([]map[Category]int, columns)
+		for i := range p.Columns {
+			p.Columns[i] = map[Category]int{}
+		}
+	}
+	if g.HasFeature(Simultaneous) {
+		p.Dices = g.newDices()
+	}
+	if h, ok := g.Handicaps[u]; ok {
+		p.Handicap = &h
+	}
+	g.Players = append(g.Players, p)
+
+	return nil
+}
+
+// Roll rolls the unlocked dices for the user.
+func (g *Game) Roll(u User) error {
+	restore, err := g.ViewAs(u)
+	if err != nil {
+		return err
+	}
+	defer restore()
+
+	if err := g.checkTurn(u); err != nil {
+		return err
+	}
+	if g.RollsLeft() == 0 {
+		return ErrNoMoreRolls
+	}
+	if g.RollCount >= g.RollsPerTurn() {
+		g.Players[g.CurrentPlayer].SavedRolls--
+	}
+
+	for i, d := range g.Dices {
+		if d.Locked {
+			continue
+		}
+
+		if g.HasFeature(Duplicate) {
+			d.Value = DuplicateRoll(g.seed(), g.Round, g.RollCount, i, g.Faces())
+		} else {
+			d.Value = g.roller().Roll(g.Faces())
+			g.RollCounter++
+		}
+		d.Face = g.FaceName(d.Value)
+	}
+
+	g.RollCount++
+
+	return nil
+}
+
+// RollsLeft returns how many times the current player can roll in this turn.
+func (g *Game) RollsLeft() int {
+	res := 0
+	if g.RollCount < g.RollsPerTurn() {
+		res = g.RollsPerTurn() - g.RollCount
+	}
+	if g.HasFeature(SavedRolls) && len(g.Players) > 0 {
+		res += g.Players[g.CurrentPlayer].SavedRolls
+	}
+	return res
+}
+
+// ToggleLock locks the dice on `index` if it was unlocked and unlocks it
+// otherwise.
+func (g *Game) ToggleLock(u User, index int) error {
+	restore, err := g.ViewAs(u)
+	if err != nil {
+		return err
+	}
+	defer restore()
+
+	if err := g.checkTurn(u); err != nil {
+		return err
+	}
+	if g.RollCount == 0 {
+		return ErrRollFirst
+	}
+	if g.RollsLeft() == 0 {
+		return ErrNoMoreRolls
+	}
+	if index < 0 || index >= len(g.Dices) {
+		return ErrInvalidDice
+	}
+
+	g.Dices[index].Locked = !g.Dices[index].Locked
+
+	return nil
+}
+
+// Score puts the current dices into the category of the user's score sheet
+// and passes the turn to the next player.
+func (g *Game) Score(u User, c Category) error {
+	return g.ScoreColumn(u, 0, c)
+}
+
+// ScoreColumn puts the current dices into the category of the user's score
+// sheet `column` and passes the turn to the next player.
+func (g *Game) ScoreColumn(u User, column int, c Category) error {
+	restore, err := g.ViewAs(u)
+	if err != nil {
+		return err
+	}
+	defer restore()
+
+	if err := g.checkTurn(u); err != nil {
+		return err
+	}
+	if g.RollCount == 0 {
+		return ErrRollFirst
+	}
+	if column < 0 || column >= g.NumberOfColumns() {
+		return ErrInvalidColumn
+	}
+
+	g.Column = column
+	sheet := g.CurrentScoreSheet()
+	hands := g.Ruleset().Hands > 0
+	if _, ok := sheet[c]; ok && !hands {
+		return ErrCategoryUsed
+	}
+	if (g.HasFeature(Ordered) || hands) && g.OrderedCategory() != c {
+		return ErrInvalidCategory
+	}
+	scorer, ok := g.Scorer.ScoreActions[c]
+	if !ok {
+		return ErrInvalidCategory
+	}
+	if err := g.CheckScoreRules(c); err != nil {
+		return err
+	}
+
+	player := g.Players[g.CurrentPlayer]
+	before := g.PlayerTotal(player)
+
+	for _, action := range g.Scorer.PreScoreActions {
+		action(g)
+	}
+
+	if hands {
+		p := g.Players[g.CurrentPlayer]
+		p.Hands = append(p.Hands, NewPokerHand(g.Dices))
+	} else {
+		sheet[c] = scorer(g)
+	}
+
+	for _, action := range g.Scorer.PostScoreActions {
+		action(g)
+	}
+
+	if g.HasFeature(Duplicate) {
+		player.Lines = append(player.Lines, g.PlayerTotal(player)-before)
+	}
+
+	if g.HasFeature(SavedRolls) && g.RollCount < g.RollsPerTurn() {
+		g.Players[g.CurrentPlayer].SavedRolls += g.RollsPerTurn() - g.RollCount
+	}
+
+	for _, d := range g.Dices {
+		d.Locked = false
+	}
+
+	g.RollCount = 0
+	switch {
+	case g.HasFeature(Simultaneous):
+		g.finishSimultaneousTurn()
+	case len(g.Teams) > 0:
+		g.nextTeamPlayer()
+	default:
+		g.CurrentPlayer = (g.CurrentPlayer + 1) % len(g.Players)
+		if g.CurrentPlayer == 0 {
+			g.Round++
+		}
+	}
+
+	if g.IsOver() {
+		for _, action := range g.Scorer.PostGameActions {
+			action(g)
+		}
+		g.applyHandicaps()
+	}
+
+	return nil
+}
+
+// OpenCategories returns the categories the current player can still score in
+// the column of the game, whatever the dices are. Nothing is open when the game
+// is over.
+func (g *Game) OpenCategories() []Category {
+	res := []Category{}
+	if len(g.Players) == 0 || g.IsOver() {
+		return res
+	}
+
+	sheet := g.CurrentScoreSheet()
+	hands := g.Ruleset().Hands > 0
+	for _, c := range g.Categories() {
+		if _, ok := g.Scorer.ScoreActions[c]; !ok {
+			continue
+		}
+		if _, ok := sheet[c]; ok && !hands {
+			continue
+		}
+		if (g.HasFeature(Ordered) || hands) && g.OrderedCategory() != c {
+			continue
+		}
+		res = append(res, c)
+	}
+	return res
+}
+
+// CheckScoreRules tells if the rules of the game's features allow to score the
+// current dices in the category.
+func (g *Game) CheckScoreRules(c Category) error {
+	for _, rule := range g.Scorer.ScoreRules {
+		if err := rule(g, c); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (g *Game) checkTurn(u User) error {
+	if len(g.Players) == 0 {
+		return ErrNoPlayers
+	}
+	if u != g.Players[g.CurrentPlayer].User {
+		return ErrNotYourTurn
+	}
+	if g.IsOver() {
+		return ErrGameOver
+	}
+	if g.Players[g.CurrentPlayer].Scored {
+		return ErrWaitingForPlayers
+	}
+	return nil
+}
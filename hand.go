@@ -0,0 +1,131 @@
+package yahtzee
+
+import "sort"
+
+// HandRank represents the formations of the Poker Dice rules.
+type HandRank string
+
+// Available hand ranks
+const (
+	Bust             HandRank = "bust"
+	PairHand         HandRank = "pair"
+	TwoPairHand      HandRank = "two-pair"
+	ThreeOfAKindHand HandRank = "three-of-a-kind"
+	StraightHand     HandRank = "straight"
+	FullHouseHand    HandRank = "full-house"
+	FourOfAKindHand  HandRank = "four-of-a-kind"
+	FiveOfAKindHand  HandRank = "five-of-a-kind"
+)
+
+// HandRanks returns the hand ranks from the lowest to the highest.
+func HandRanks() []HandRank {
+	return []HandRank{
+		Bust,
+		PairHand,
+		TwoPairHand,
+		ThreeOfAKindHand,
+		StraightHand,
+		FullHouseHand,
+		FourOfAKindHand,
+		FiveOfAKindHand,
+	}
+}
+
+// Value returns the position of the rank in HandRanks.
+func (r HandRank) Value() int {
+	for i, rank := range HandRanks() {
+		if rank == r {
+			return i
+		}
+	}
+	return -1
+}
+
+// PokerHand is the formation a player shows at the end of a Poker Dice turn.
+type PokerHand struct {
+	// Rank is the formation of the dices
+	Rank HandRank
+
+	// Values has the dice values in the order they break the ties: the bigger
+	// groups first, the higher values first within the same group size
+	Values []int
+}
+
+// NewPokerHand returns the hand made by the dices.
+func NewPokerHand(dices []*Dice) PokerHand {
+	counts := occurrences(dices)
+	values := make([]int, len(dices))
+	for i, d := range dices {
+		values[i] = d.Value
+	}
+	sort.Slice(values, func(i, j int) bool {
+		if counts[values[i]] != counts[values[j]] {
+			return counts[values[i]] > counts[values[j]]
+		}
+		return values[i] > values[j]
+	})
+
+	groups := []int{}
+	for _, c := range counts {
+		groups = append(groups, c)
+	}
+	sort.Sort(sort.Reverse(sort.IntSlice(groups)))
+
+	rank := Bust
+	switch {
+	case groups[0] >= 5:
+		rank = FiveOfAKindHand
+	case groups[0] == 4:
+		rank = FourOfAKindHand
+	case groups[0] == 3 && len(groups) > 1 && groups[1] >= 2:
+		rank = FullHouseHand
+	case longestStraight(dices) >= 5:
+		rank = StraightHand
+	case groups[0] == 3:
+		rank = ThreeOfAKindHand
+	case groups[0] == 2 && len(groups) > 1 && groups[1] == 2:
+		rank = TwoPairHand
+	case groups[0] == 2:
+		rank = PairHand
+	}
+
+	return PokerHand{
+		Rank:   rank,
+		Values: values,
+	}
+}
+
+// Compare returns a positive number when the hand beats the `other`, a
+// negative one when it loses and zero on a tie.
+func (h PokerHand) Compare(other PokerHand) int {
+	if d := h.Rank.Value() - other.Rank.Value(); d != 0 {
+		return d
+	}
+	for i := 0; i < len(h.Values) && i < len(other.Values); i++ {
+		if d := h.Values[i] - other.Values[i]; d != 0 {
+			return d
+		}
+	}
+	return 0
+}
+
+// RoundWinners returns the users with the best hand in the `round`. It's
+// empty until every player showed a hand in the round.
+func (g *Game) RoundWinners(round int) []User {
+	res := []User{}
+	var best PokerHand
+	for _, p := range g.Players {
+		if round >= len(p.Hands) {
+			return []User{}
+		}
+		h := p.Hands[round]
+		switch c := h.Compare(best); {
+		case len(res) == 0 || c > 0:
+			best = h
+			res = []User{p.User}
+		case c == 0:
+			res = append(res, p.User)
+		}
+	}
+	return res
+}
@@ -0,0 +1,146 @@
+package yahtzee
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+)
+
+// HandicapCategory keeps the handicap points of the player in the score sheet
+// when the game is over.
+const HandicapCategory Category = "handicap"
+
+const (
+	// MaxHandicapPoints is the most points a handicap can add.
+	MaxHandicapPoints = 200
+
+	// MaxHandicapPercent is the biggest percentage a handicap can add.
+	MaxHandicapPercent = 100
+)
+
+var (
+	// ErrInvalidHandicap is returned when the handicap is negative or too big.
+	ErrInvalidHandicap = errors.New("invalid handicap")
+
+	// ErrHandicapPreset is returned when a player sets the handicap the creator
+	// of the game already set.
+	ErrHandicapPreset = errors.New("handicap set by the creator")
+
+	// ErrHandicapUnsupported is returned when a handicap is set for the rules
+	// deciding the game by the rounds won instead of the points.
+	ErrHandicapUnsupported = errors.New("handicaps not supported by the rules")
+)
+
+// Handicap gives extra points to a player at the end of the game.
+type Handicap struct {
+	// Points is added to the total
+	Points int `json:",omitempty"`
+
+	// Percent of the total is added to the total
+	Percent int `json:",omitempty"`
+}
+
+// ParseHandicap reads a handicap of fixed points (eg. "20") or of a
+// percentage (eg. "15%").
+func ParseHandicap(s string) (Handicap, error) {
+	var res Handicap
+	if strings.HasSuffix(s, "%") {
+		percent, err := strconv.Atoi(strings.TrimSuffix(s, "%"))
+		if err != nil {
+			return res, ErrInvalidHandicap
+		}
+		res.Percent = percent
+	} else {
+		points, err := strconv.Atoi(s)
+		if err != nil {
+			return res, ErrInvalidHandicap
+		}
+		res.Points = points
+	}
+	return res, res.Validate()
+}
+
+// Validate checks that the handicap gives no negative points, at most
+// MaxHandicapPoints points and at most MaxHandicapPercent percent.
+func (h Handicap) Validate() error {
+	if h.Points < 0 || h.Points > MaxHandicapPoints || h.Percent < 0 || h.Percent > MaxHandicapPercent {
+		return ErrInvalidHandicap
+	}
+	return nil
+}
+
+// Of returns the points the handicap gives for the `total`.
+func (h Handicap) Of(total int) int {
+	return h.Points + total*h.Percent/100
+}
+
+// PresetHandicaps sets the handicaps the creator of the game chose for the
+// users.
+func (g *Game) PresetHandicaps(handicaps map[User]Handicap) error {
+	if len(handicaps) == 0 {
+		return nil
+	}
+	if g.Ruleset().Hands > 0 {
+		return ErrHandicapUnsupported
+	}
+	for _, h := range handicaps {
+		if err := h.Validate(); err != nil {
+			return err
+		}
+	}
+	g.Handicaps = handicaps
+	return nil
+}
+
+// SetHandicap sets the handicap of the player before the game starts. The
+// handicaps set by the creator of the game can't be changed.
+func (g *Game) SetHandicap(u User, h Handicap) error {
+	if g.started() {
+		return ErrAlreadyStarted
+	}
+	if g.Ruleset().Hands > 0 {
+		return ErrHandicapUnsupported
+	}
+	if err := h.Validate(); err != nil {
+		return err
+	}
+	if _, ok := g.Handicaps[u]; ok {
+		return ErrHandicapPreset
+	}
+	p := g.Player(u)
+	if p == nil {
+		return ErrNotJoined
+	}
+	p.Handicap = &h
+	return nil
+}
+
+// HandicapPoints returns the points the handicap gives to the player for the
+// current total. In the Teams mode the handicaps of the teammates are added
+// up for the team.
+func (g *Game) HandicapPoints(p *Player) int {
+	h := g.handicapOf(p)
+	return h.Of(g.PlayerTotal(p) - g.PlayerSheet(p, 0)[HandicapCategory])
+}
+
+func (g *Game) handicapOf(p *Player) Handicap {
+	res := Handicap{}
+	for _, other := range g.Players {
+		if other == p || (p.Team != "" && other.Team == p.Team) {
+			if other.Handicap != nil {
+				res.Points += other.Handicap.Points
+				res.Percent += other.Handicap.Percent
+			}
+		}
+	}
+	return res
+}
+
+// applyHandicaps puts the handicap points into the score sheet of the players.
+func (g *Game) applyHandicaps() {
+	for _, p := range g.Players {
+		if points := g.HandicapPoints(p); points > 0 {
+			g.PlayerSheet(p, 0)[HandicapCategory] = points
+		}
+	}
+}
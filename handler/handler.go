@@ -1,20 +1,24 @@
 package handler
 
 import (
+	"bytes"
 	"encoding/json"
 	"errors"
 	"fmt"
-	"io"
 	"io/ioutil"
 	"log"
 	"math/rand"
 	"net/http"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/akarasz/yahtzee"
+	"github.com/akarasz/yahtzee/analysis"
+	"github.com/akarasz/yahtzee/bot"
 	"github.com/akarasz/yahtzee/event"
+	"github.com/akarasz/yahtzee/solver"
 	"github.com/akarasz/yahtzee/store"
 	"github.com/gorilla/mux"
 	"github.com/gorilla/websocket"
@@ -24,10 +28,24 @@ type handler struct {
 	store      store.Store
 	emitter    event.Emitter
 	subscriber event.Subscriber
+
+	// botsLock guards the games the bots are playing in
+	botsLock sync.Mutex
+	// playing has the games a goroutine plays the bots of, and if it has to
+	// check the game again before it stops
+	playing map[string]bool
 }
 
+// BotDelay is the least time a bot waits before its moves.
+var BotDelay = 700 * time.Millisecond
+
 func New(s store.Store, e event.Emitter, sub event.Subscriber) http.Handler {
-	h := &handler{s, e, sub}
+	h := &handler{
+		store:      s,
+		emitter:    e,
+		subscriber: sub,
+		playing:    map[string]bool{},
+	}
 
 	r := mux.NewRouter()
 	r.Use(corsMiddleware)
@@ -35,20 +53,40 @@ func New(s store.Store, e event.Emitter, sub event.Subscriber) http.Handler {
 		Methods("POST", "OPTIONS")
 	r.HandleFunc("/score", h.Hints).
 		Methods("GET", "OPTIONS")
+	r.HandleFunc("/score", h.HintsForDices).
+		Methods("POST")
+	r.HandleFunc("/odds", h.OddsForDices).
+		Methods("POST", "OPTIONS")
 	r.HandleFunc("/features", h.Features).
 		Methods("GET", "OPTIONS")
 	r.HandleFunc("/{gameID}", h.Get).
 		Methods("GET", "OPTIONS")
 	r.HandleFunc("/{gameID}/hints", h.HintsForGame).
 		Methods("GET", "OPTIONS")
+	r.HandleFunc("/{gameID}/odds", h.Odds).
+		Methods("GET", "OPTIONS")
 	r.HandleFunc("/{gameID}/join", h.AddPlayer).
 		Methods("POST", "OPTIONS")
+	r.HandleFunc("/{gameID}/bots", h.AddBot).
+		Methods("POST", "OPTIONS")
+	r.HandleFunc("/{gameID}/start", h.Start).
+		Methods("POST", "OPTIONS")
 	r.HandleFunc("/{gameID}/roll", h.Roll).
 		Methods("POST", "OPTIONS")
 	r.HandleFunc("/{gameID}/lock/{dice}", h.Lock).
 		Methods("POST", "OPTIONS")
 	r.HandleFunc("/{gameID}/score", h.Score).
 		Methods("POST", "OPTIONS")
+	r.HandleFunc("/{gameID}/results", h.Results).
+		Methods("GET", "OPTIONS")
+	r.HandleFunc("/{gameID}/analysis", h.Analysis).
+		Methods("GET", "OPTIONS")
+	r.HandleFunc("/{gameID}/undo", h.RequestUndo).
+		Methods("POST", "OPTIONS")
+	r.HandleFunc("/{gameID}/undo/approve", h.ApproveUndo).
+		Methods("POST", "OPTIONS")
+	r.HandleFunc("/{gameID}/undo/decline", h.DeclineUndo).
+		Methods("POST", "OPTIONS")
 	r.HandleFunc("/{gameID}/ws", h.WS)
 	return r
 }
@@ -81,17 +119,36 @@ func generateID() string {
 	return string(b)
 }
 
+type CreateRequest struct {
+	Features  []yahtzee.Feature
+	Options   yahtzee.GameOptions
+	Handicaps map[yahtzee.User]yahtzee.Handicap
+}
+
 func (h *handler) Create(w http.ResponseWriter, r *http.Request) {
 	gameID := generateID()
-	features := []yahtzee.Feature{}
-	if r.Body != nil {
-		err := json.NewDecoder(r.Body).Decode(&features)
-		if err != nil && err != io.EOF {
-			writeError(w, r, err, "create game", http.StatusBadRequest)
-			return
-		}
+	req, ok := readCreateRequest(w, r)
+	if !ok {
+		return
+	}
+	features, err := yahtzee.ResolveFeatures(req.Features...)
+	if err != nil {
+		writeValidationError(w, r, err)
+		return
 	}
-	if err := h.store.Save(gameID, *yahtzee.NewGame(features...)); err != nil {
+	if err := req.Options.Validate(features...); err != nil {
+		writeValidationError(w, r, err)
+		return
+	}
+	g := yahtzee.NewGameWithOptions(req.Options, features...)
+	if err := g.PresetHandicaps(req.Handicaps); err != nil {
+		writeValidationError(w, r, err)
+		return
+	}
+	if user, _, ok := r.BasicAuth(); ok {
+		g.Creator = yahtzee.User(user)
+	}
+	if err := h.store.Save(gameID, *g); err != nil {
 		writeError(w, r, err, "create game", http.StatusInternalServerError)
 		return
 	}
@@ -107,6 +164,10 @@ func (h *handler) HintsForGame(w http.ResponseWriter, r *http.Request) {
 	if !ok {
 		return
 	}
+	column, ok := readColumn(w, r)
+	if !ok {
+		return
+	}
 
 	unlocker, err := h.store.Lock(gameID)
 	if err != nil {
@@ -121,62 +182,315 @@ func (h *handler) HintsForGame(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if column >= g.NumberOfColumns() {
+		writeError(w, r, nil, "invalid column", http.StatusBadRequest)
+		return
+	}
+	g.Column = column
+
+	if g.HasFeature(yahtzee.Simultaneous) {
+		user, ok := readUser(w, r)
+		if !ok {
+			return
+		}
+		restore, err := g.ViewAs(user)
+		if err != nil {
+			writeGameError(w, r, err)
+			return
+		}
+		defer restore()
+	}
+
 	res, err := hints(&g)
 	if err != nil {
 		writeError(w, r, err, "", http.StatusInternalServerError)
 		return
 	}
 
-	if ok := writeJSON(w, r, res); !ok {
+	if r.URL.Query().Get("expected") == "true" {
+		expectation, err := g.Expect()
+		if err != nil {
+			writeGameError(w, r, err)
+			return
+		}
+		if ok := writeJSON(w, r, &ExpectedHintsResponse{*res, expectation}); !ok {
+			return
+		}
+		log.Print("expected hints for game returned")
+		return
+	}
+
+	if ok := writeHints(w, r, res); !ok {
 		return
 	}
 
 	log.Print("hints for game returned")
 }
 
-func hints(game *yahtzee.Game) (map[yahtzee.Category]int, error) {
-	res := map[yahtzee.Category]int{}
+type HintsResponse struct {
+	// Points has the points of the categories for the current dices
+	Points map[yahtzee.Category]int
+
+	// Allowed tells if the rules allow scoring the current dices in the
+	// categories; the disallowed ones are worth zero points
+	Allowed map[yahtzee.Category]bool
+}
+
+type ExpectedHintsResponse struct {
+	HintsResponse
+
+	*yahtzee.Expectation
+}
+
+func hints(game *yahtzee.Game) (*HintsResponse, error) {
+	res := &HintsResponse{
+		Points:  map[yahtzee.Category]int{},
+		Allowed: map[yahtzee.Category]bool{},
+	}
 	for c, scorer := range game.Scorer.ScoreActions {
-		res[c] = scorer(game)
-		if game.HasFeature(yahtzee.Ordered) && game.Round < len(yahtzee.Categories()) && yahtzee.Categories()[game.Round] != c {
-			res[c] = 0
+		res.Allowed[c] = game.CheckScoreRules(c) == nil
+		if !res.Allowed[c] {
+			res.Points[c] = 0
+			continue
+		}
+		res.Points[c] = scorer(game)
+		if game.HasFeature(yahtzee.Ordered) && !game.IsOver() && game.OrderedCategory() != c {
+			res.Points[c] = 0
 		}
 	}
 
 	return res, nil
 }
 
+// writeHints writes the points of the hints, or the whole hints when the
+// request asks for the allowed categories too.
+func writeHints(w http.ResponseWriter, r *http.Request, res *HintsResponse) bool {
+	if r.URL.Query().Get("allowed") == "true" {
+		return writeJSON(w, r, res)
+	}
+	return writeJSON(w, r, res.Points)
+}
+
 func (h *handler) Hints(w http.ResponseWriter, r *http.Request) {
 	features, ok := readFeatures(w, r)
 	if !ok {
 		return
 	}
 
-	diceNum := 5
-	if yahtzee.ContainsFeature(features, yahtzee.SixDice) {
-		diceNum = 6
+	game := yahtzee.NewGame(features...)
+	dices, ok := readDices(w, r, len(game.Dices), game.Ruleset().Faces)
+	if !ok {
+		return
+	}
+
+	req := &ScoreRequest{
+		Dices:    dices,
+		Features: features,
+	}
+	if yahtzee.ContainsFeature(features, yahtzee.YahtzeeBonus) {
+		// this endpoint always used the joker values for a yahtzee
+		req.ScoreSheet = map[yahtzee.Category]int{
+			yahtzee.Yahtzee: 50,
+		}
+	}
+
+	g, err := newScoringGame(req)
+	if err != nil {
+		writeError(w, r, err, "invalid dices", http.StatusBadRequest)
+		return
+	}
+
+	res, err := hints(g)
+	if err != nil {
+		writeError(w, r, err, "", http.StatusInternalServerError)
+		return
+	}
+
+	if ok := writeHints(w, r, res); !ok {
+		return
+	}
+
+	log.Print("hints returned")
+}
+
+type ScoreRequest struct {
+	Dices      []int
+	Features   []yahtzee.Feature
+	Options    yahtzee.GameOptions
+	ScoreSheet map[yahtzee.Category]int
+}
+
+func (h *handler) HintsForDices(w http.ResponseWriter, r *http.Request) {
+	var req ScoreRequest
+	if r.Body == nil {
+		writeError(w, r, nil, "no body", http.StatusBadRequest)
+		return
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, r, err, "invalid body", http.StatusBadRequest)
+		return
+	}
+
+	g, err := newScoringGame(&req)
+	if err != nil {
+		writeValidationError(w, r, err)
+		return
+	}
+
+	res, err := hints(g)
+	if err != nil {
+		writeError(w, r, err, "", http.StatusInternalServerError)
+		return
 	}
 
-	dices, ok := readDices(w, r, diceNum)
+	if ok := writeHints(w, r, res); !ok {
+		return
+	}
+
+	log.Print("hints for dices returned")
+}
+
+func (h *handler) Odds(w http.ResponseWriter, r *http.Request) {
+	gameID, ok := readGameID(w, r)
+	if !ok {
+		return
+	}
+	column, ok := readColumn(w, r)
 	if !ok {
 		return
 	}
 
-	res := map[yahtzee.Category]int{}
-	for _, c := range yahtzee.Categories() {
-		score, err := score(c, dices, yahtzee.ContainsFeature(features, yahtzee.YahtzeeBonus))
+	unlocker, err := h.store.Lock(gameID)
+	if err != nil {
+		writeError(w, r, err, "locking issue", http.StatusInternalServerError)
+		return
+	}
+	defer unlocker()
+
+	g, err := h.store.Load(gameID)
+	if err != nil {
+		writeStoreError(w, r, err)
+		return
+	}
+
+	if column >= g.NumberOfColumns() {
+		writeError(w, r, nil, "invalid column", http.StatusBadRequest)
+		return
+	}
+	g.Column = column
+
+	if g.HasFeature(yahtzee.Simultaneous) {
+		user, ok := readUser(w, r)
+		if !ok {
+			return
+		}
+		restore, err := g.ViewAs(user)
 		if err != nil {
-			writeError(w, r, err, "", http.StatusInternalServerError)
+			writeGameError(w, r, err)
 			return
 		}
-		res[c] = score
+		defer restore()
+	}
+
+	res, err := g.Odds()
+	if err != nil {
+		writeGameError(w, r, err)
+		return
 	}
 
 	if ok := writeJSON(w, r, res); !ok {
 		return
 	}
 
-	log.Print("hints returned")
+	log.Print("odds for game returned")
+}
+
+type OddsRequest struct {
+	ScoreRequest
+
+	// Locked has the indices of the locked dices
+	Locked []int
+
+	// RollCount shows how many times the dices were rolled in the turn; it's 1
+	// when not set
+	RollCount int
+}
+
+func (h *handler) OddsForDices(w http.ResponseWriter, r *http.Request) {
+	var req OddsRequest
+	if r.Body == nil {
+		writeError(w, r, nil, "no body", http.StatusBadRequest)
+		return
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, r, err, "invalid body", http.StatusBadRequest)
+		return
+	}
+
+	g, err := newScoringGame(&req.ScoreRequest)
+	if err != nil {
+		writeValidationError(w, r, err)
+		return
+	}
+	for _, i := range req.Locked {
+		if i < 0 || i >= len(g.Dices) {
+			writeValidationError(w, r, yahtzee.ErrInvalidDice)
+			return
+		}
+		g.Dices[i].Locked = true
+	}
+	if req.RollCount > 0 {
+		g.RollCount = req.RollCount
+	}
+
+	res, err := g.Odds()
+	if err != nil {
+		writeValidationError(w, r, err)
+		return
+	}
+
+	if ok := writeJSON(w, r, res); !ok {
+		return
+	}
+
+	log.Print("odds for dices returned")
+}
+
+// newScoringGame creates a throwaway game where a single player is about to
+// score the dices of the request.
+func newScoringGame(req *ScoreRequest) (*yahtzee.Game, error) {
+	features, err := yahtzee.ResolveFeatures(req.Features...)
+	if err != nil {
+		return nil, err
+	}
+	if err := req.Options.Validate(features...); err != nil {
+		return nil, err
+	}
+	g := yahtzee.NewGameWithOptions(req.Options, features...)
+	if len(req.Dices) != len(g.Dices) {
+		return nil, errors.New("wrong number of dices")
+	}
+	for i, v := range req.Dices {
+		if v < 1 || g.Faces() < v {
+			return nil, errors.New("invalid dice")
+		}
+		g.Dices[i].Value = v
+	}
+
+	p := yahtzee.NewPlayer("")
+	for c, v := range req.ScoreSheet {
+		p.ScoreSheet[c] = v
+	}
+	for _, c := range g.Categories() {
+		if _, ok := p.ScoreSheet[c]; ok {
+			g.Round++
+		}
+	}
+
+	g.Players = []*yahtzee.Player{p}
+	g.RollCount = 1
+
+	return g, nil
 }
 
 func (h *handler) Get(w http.ResponseWriter, r *http.Request) {
@@ -198,18 +512,406 @@ func (h *handler) Get(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if ok := writeJSON(w, r, g); !ok {
+	if ok := writeJSON(w, r, gameResponse(&g)); !ok {
+		return
+	}
+
+	log.Print("game returned")
+}
+
+// GameResponse is the game as the players see it. The seed tells every dice of
+// the game, so it's kept secret until the game is over.
+type GameResponse struct {
+	*yahtzee.Game
+
+	// Seed and RollCounter are only present when the game is over
+	Seed        int64 `json:",omitempty"`
+	RollCounter int   `json:",omitempty"`
+}
+
+func gameResponse(g *yahtzee.Game) *GameResponse {
+	res := &GameResponse{
+		Game: g,
+	}
+	if g.IsOver() {
+		res.Seed, res.RollCounter = g.Seed, g.RollCounter
+	}
+	return res
+}
+
+type AddPlayerResponse struct {
+	Players []*yahtzee.Player
+
+	// Teams is only present in the Teams mode
+	Teams []*yahtzee.Team `json:",omitempty"`
+}
+
+func (h *handler) AddPlayer(w http.ResponseWriter, r *http.Request) {
+	user, ok := readUser(w, r)
+	if !ok {
+		return
+	}
+	gameID, ok := readGameID(w, r)
+	if !ok {
+		return
+	}
+	handicap, ok := readHandicap(w, r)
+	if !ok {
+		return
+	}
+
+	unlocker, err := h.store.Lock(gameID)
+	if err != nil {
+		writeError(w, r, err, "locking issue", http.StatusInternalServerError)
+		return
+	}
+	defer unlocker()
+
+	g, err := h.store.Load(gameID)
+	if err != nil {
+		writeStoreError(w, r, err)
+		return
+	}
+
+	if err := g.AddPlayerToTeam(user, r.URL.Query().Get("team")); err != nil {
+		writeGameError(w, r, err)
+		return
+	}
+	if handicap != nil {
+		if err := g.SetHandicap(user, *handicap); err != nil {
+			writeGameError(w, r, err)
+			return
+		}
+	}
+
+	if err := h.store.Save(gameID, g); err != nil {
+		writeStoreError(w, r, err)
+		return
+	}
+
+	changes := &AddPlayerResponse{
+		Players: g.Players,
+		Teams:   g.Teams,
+	}
+
+	h.emitter.Emit(gameID, &user, event.AddPlayer, changes)
+
+	w.WriteHeader(http.StatusCreated)
+	if ok := writeJSON(w, r, changes); !ok {
+		return
+	}
+
+	log.Print("player added")
+}
+
+func (h *handler) AddBot(w http.ResponseWriter, r *http.Request) {
+	user, ok := readUser(w, r)
+	if !ok {
+		return
+	}
+	gameID, ok := readGameID(w, r)
+	if !ok {
+		return
+	}
+	difficulty := yahtzee.Difficulty(r.URL.Query().Get("difficulty"))
+	if difficulty == "" {
+		difficulty = yahtzee.Greedy
+	}
+
+	unlocker, err := h.store.Lock(gameID)
+	if err != nil {
+		writeError(w, r, err, "locking issue", http.StatusInternalServerError)
+		return
+	}
+	defer unlocker()
+
+	g, err := h.store.Load(gameID)
+	if err != nil {
+		writeStoreError(w, r, err)
+		return
+	}
+
+	if g.Creator != "" && g.Creator != user {
+		writeGameError(w, r, yahtzee.ErrNotCreator)
+		return
+	}
+	if err := bot.Supports(&g, difficulty); err != nil {
+		writeValidationError(w, r, err)
+		return
+	}
+
+	name := yahtzee.User(r.URL.Query().Get("name"))
+	if name == "" {
+		name = yahtzee.User(fmt.Sprintf("Bot %d", len(g.Players)+1))
+	}
+	if err := g.AddBot(name, difficulty, r.URL.Query().Get("team")); err != nil {
+		writeGameError(w, r, err)
+		return
+	}
+
+	if err := h.store.Save(gameID, g); err != nil {
+		writeStoreError(w, r, err)
+		return
+	}
+
+	changes := &AddPlayerResponse{
+		Players: g.Players,
+		Teams:   g.Teams,
+	}
+
+	h.emitter.Emit(gameID, &name, event.AddPlayer, changes)
+
+	w.WriteHeader(http.StatusCreated)
+	if ok := writeJSON(w, r, changes); !ok {
+		return
+	}
+
+	log.Print("bot added")
+}
+
+func (h *handler) Start(w http.ResponseWriter, r *http.Request) {
+	user, ok := readUser(w, r)
+	if !ok {
+		return
+	}
+	gameID, ok := readGameID(w, r)
+	if !ok {
+		return
+	}
+
+	unlocker, err := h.store.Lock(gameID)
+	if err != nil {
+		writeError(w, r, err, "locking issue", http.StatusInternalServerError)
+		return
+	}
+	defer unlocker()
+
+	g, err := h.store.Load(gameID)
+	if err != nil {
+		writeStoreError(w, r, err)
+		return
+	}
+
+	if g.Creator != "" && g.Creator != user {
+		writeGameError(w, r, yahtzee.ErrNotCreator)
+		return
+	}
+	if err := g.Start(); err != nil {
+		writeGameError(w, r, err)
+		return
+	}
+
+	if err := h.store.Save(gameID, g); err != nil {
+		writeStoreError(w, r, err)
+		return
+	}
+
+	changes := gameResponse(&g)
+
+	h.emitter.Emit(gameID, &user, event.Start, changes)
+	h.playBots(gameID)
+
+	if ok := writeJSON(w, r, changes); !ok {
+		return
+	}
+
+	log.Print("game started")
+}
+
+// playBots makes the bots of the game play while it's their turn. A single
+// goroutine plays the bots of a game.
+func (h *handler) playBots(gameID string) {
+	h.botsLock.Lock()
+	defer h.botsLock.Unlock()
+
+	if _, ok := h.playing[gameID]; ok {
+		h.playing[gameID] = true
+		return
+	}
+	h.playing[gameID] = false
+
+	delay := BotDelay
+	go func() {
+		for {
+			time.Sleep(delay + time.Duration(rand.Int63n(int64(delay)+1)))
+			if h.playBot(gameID) {
+				continue
+			}
+
+			h.botsLock.Lock()
+			again := h.playing[gameID]
+			if again {
+				h.playing[gameID] = false
+			} else {
+				delete(h.playing, gameID)
+			}
+			h.botsLock.Unlock()
+			if !again {
+				return
+			}
+		}
+	}()
+}
+
+// playBot makes the next move of the bot who has to act in the game, and
+// tells if there was one.
+func (h *handler) playBot(gameID string) bool {
+	unlocker, err := h.store.Lock(gameID)
+	if err != nil {
+		log.Printf("locking issue: %v", err)
+		return false
+	}
+	defer unlocker()
+
+	g, err := h.store.Load(gameID)
+	if err != nil {
+		log.Printf("bot load game: %v", err)
+		return false
+	}
+
+	p := g.NextBot()
+	if p == nil {
+		return false
+	}
+	user := p.User
+	move, err := bot.Next(&g, p)
+	if err != nil {
+		log.Printf("bot move: %v", err)
+		return false
+	}
+
+	var t event.Type
+	var changes interface{}
+	var history *yahtzee.Move
+	switch move.Kind {
+	case bot.Roll:
+		err = g.Roll(user)
+		t, changes = event.Roll, rollResponse(&g, user)
+		if err == nil {
+			m := g.RollMove(user)
+			history = &m
+		}
+	case bot.Lock:
+		err = g.ToggleLock(user, move.Dice)
+		t, changes = event.Lock, lockResponse(&g, user)
+	case bot.Score:
+		m := g.ScoreMove(user, move.Column, move.Category)
+		history = &m
+		err = g.ScoreColumn(user, move.Column, move.Category)
+		t, changes = event.Score, scoreResponse(&g)
+	}
+	if err != nil {
+		log.Printf("bot %s: %v", move.Kind, err)
+		return false
+	}
+
+	if err := h.store.Save(gameID, g); err != nil {
+		log.Printf("bot save game: %v", err)
+		return false
+	}
+	if err := h.store.DeleteUndo(gameID); err != nil {
+		log.Printf("bot delete undo: %v", err)
+		return false
+	}
+	if history != nil {
+		if err := h.store.AddMove(gameID, *history); err != nil {
+			log.Printf("bot add move: %v", err)
+			return false
+		}
+	}
+
+	h.emitter.Emit(gameID, &user, t, changes)
+
+	log.Printf("bot %s", move.Kind)
+	return true
+}
+
+type RollResponse struct {
+	// User is the player whose dices were rolled in a Simultaneous game
+	User yahtzee.User `json:",omitempty"`
+
+	Dices      []*yahtzee.Dice
+	RollCount  int
+	SavedRolls int `json:",omitempty"`
+}
+
+func (h *handler) Roll(w http.ResponseWriter, r *http.Request) {
+	user, ok := readUser(w, r)
+	if !ok {
+		return
+	}
+	gameID, ok := readGameID(w, r)
+	if !ok {
+		return
+	}
+
+	unlocker, err := h.store.Lock(gameID)
+	if err != nil {
+		writeError(w, r, err, "locking issue", http.StatusInternalServerError)
+		return
+	}
+	defer unlocker()
+
+	g, err := h.store.Load(gameID)
+	if err != nil {
+		writeStoreError(w, r, err)
+		return
+	}
+
+	if err := g.Roll(user); err != nil {
+		writeGameError(w, r, err)
+		return
+	}
+
+	if err := h.store.Save(gameID, g); err != nil {
+		writeStoreError(w, r, err)
+		return
+	}
+	if err := h.store.DeleteUndo(gameID); err != nil {
+		writeStoreError(w, r, err)
+		return
+	}
+	if err := h.store.AddMove(gameID, g.RollMove(user)); err != nil {
+		writeStoreError(w, r, err)
+		return
+	}
+
+	changes := rollResponse(&g, user)
+
+	h.emitter.Emit(gameID, &user, event.Roll, changes)
+	if g.HasFeature(yahtzee.Simultaneous) {
+		// the first roll of a human starts the bots too
+		h.playBots(gameID)
+	}
+
+	if ok := writeJSON(w, r, changes); !ok {
 		return
 	}
 
-	log.Print("game returned")
+	log.Print("rolled dices")
 }
 
-type AddPlayerResponse struct {
-	Players []*yahtzee.Player
+func rollResponse(g *yahtzee.Game, user yahtzee.User) *RollResponse {
+	p := g.Player(user)
+	res := &RollResponse{
+		Dices:      g.DicesOf(p),
+		RollCount:  g.RollCountOf(p),
+		SavedRolls: p.SavedRolls,
+	}
+	if g.HasFeature(yahtzee.Simultaneous) {
+		res.User = user
+	}
+	return res
 }
 
-func (h *handler) AddPlayer(w http.ResponseWriter, r *http.Request) {
+type LockResponse struct {
+	// User is the player whose dice was toggled in a Simultaneous game
+	User yahtzee.User `json:",omitempty"`
+
+	Dices []*yahtzee.Dice
+}
+
+func (h *handler) Lock(w http.ResponseWriter, r *http.Request) {
 	user, ok := readUser(w, r)
 	if !ok {
 		return
@@ -218,6 +920,10 @@ func (h *handler) AddPlayer(w http.ResponseWriter, r *http.Request) {
 	if !ok {
 		return
 	}
+	diceIndex, ok := readDiceIndex(w, r)
+	if !ok {
+		return
+	}
 
 	unlocker, err := h.store.Lock(gameID)
 	if err != nil {
@@ -232,44 +938,50 @@ func (h *handler) AddPlayer(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if g.CurrentPlayer > 0 || g.Round > 0 {
-		writeError(w, r, nil, "game already started", http.StatusBadRequest)
+	undo := yahtzee.NewUndo(&g, user)
+	if err := g.ToggleLock(user, diceIndex); err != nil {
+		writeGameError(w, r, err)
 		return
 	}
-	for _, p := range g.Players {
-		if p.User == user {
-			writeError(w, r, nil, "already joined", http.StatusConflict)
-			return
-		}
-	}
-
-	g.Players = append(g.Players, yahtzee.NewPlayer(user))
 
 	if err := h.store.Save(gameID, g); err != nil {
 		writeStoreError(w, r, err)
 		return
 	}
-
-	changes := &AddPlayerResponse{
-		Players: g.Players,
+	if err := h.store.SaveUndo(gameID, *undo); err != nil {
+		writeStoreError(w, r, err)
+		return
 	}
 
-	h.emitter.Emit(gameID, &user, event.AddPlayer, changes)
+	changes := lockResponse(&g, user)
+
+	h.emitter.Emit(gameID, &user, event.Lock, changes)
 
-	w.WriteHeader(http.StatusCreated)
 	if ok := writeJSON(w, r, changes); !ok {
 		return
 	}
 
-	log.Print("player added")
+	log.Print("toggled dice")
 }
 
-type RollResponse struct {
-	Dices     []*yahtzee.Dice
-	RollCount int
+func lockResponse(g *yahtzee.Game, user yahtzee.User) *LockResponse {
+	res := &LockResponse{
+		Dices: g.DicesOf(g.Player(user)),
+	}
+	if g.HasFeature(yahtzee.Simultaneous) {
+		res.User = user
+	}
+	return res
 }
 
-func (h *handler) Roll(w http.ResponseWriter, r *http.Request) {
+type ScoreResponse struct {
+	GameResponse
+
+	// Standings is only present when the game is over
+	Standings []yahtzee.Standing `json:",omitempty"`
+}
+
+func (h *handler) Score(w http.ResponseWriter, r *http.Request) {
 	user, ok := readUser(w, r)
 	if !ok {
 		return
@@ -278,6 +990,14 @@ func (h *handler) Roll(w http.ResponseWriter, r *http.Request) {
 	if !ok {
 		return
 	}
+	category, ok := readCategory(w, r)
+	if !ok {
+		return
+	}
+	column, ok := readColumn(w, r)
+	if !ok {
+		return
+	}
 
 	unlocker, err := h.store.Lock(gameID)
 	if err != nil {
@@ -292,62 +1012,55 @@ func (h *handler) Roll(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if len(g.Players) == 0 {
-		writeError(w, r, nil, "no players joined", http.StatusBadRequest)
-		return
-	}
-	currentPlayer := g.Players[g.CurrentPlayer]
-	if user != currentPlayer.User {
-		writeError(w, r, nil, "another players turn", http.StatusBadRequest)
+	undo := yahtzee.NewUndo(&g, user)
+	move := g.ScoreMove(user, column, category)
+	if err := g.ScoreColumn(user, column, category); err != nil {
+		writeGameError(w, r, err)
 		return
 	}
-	if g.Round >= 13 {
-		writeError(w, r, nil, "game is over", http.StatusBadRequest)
+
+	if err := h.store.Save(gameID, g); err != nil {
+		writeStoreError(w, r, err)
 		return
 	}
-	if g.RollCount >= 3 {
-		writeError(w, r, nil, "no more rolls", http.StatusBadRequest)
+	if err := h.store.SaveUndo(gameID, *undo); err != nil {
+		writeStoreError(w, r, err)
 		return
 	}
-
-	for _, d := range g.Dices {
-		if d.Locked {
-			continue
-		}
-
-		d.Value = rand.Intn(6) + 1
-	}
-
-	g.RollCount++
-
-	if err := h.store.Save(gameID, g); err != nil {
+	if err := h.store.AddMove(gameID, move); err != nil {
 		writeStoreError(w, r, err)
 		return
 	}
 
-	changes := &RollResponse{
-		Dices:     g.Dices,
-		RollCount: g.RollCount,
-	}
+	changes := scoreResponse(&g)
 
-	h.emitter.Emit(gameID, &user, event.Roll, changes)
+	h.emitter.Emit(gameID, &user, event.Score, changes)
+	h.playBots(gameID)
 
 	if ok := writeJSON(w, r, changes); !ok {
 		return
 	}
 
-	log.Print("rolled dices")
+	log.Print("scored")
 }
 
-type LockResponse struct {
-	Dices []*yahtzee.Dice
+func scoreResponse(g *yahtzee.Game) *ScoreResponse {
+	res := &ScoreResponse{
+		GameResponse: *gameResponse(g),
+	}
+	if g.IsOver() {
+		res.Standings = g.Standings()
+	}
+	return res
 }
 
-func (h *handler) Lock(w http.ResponseWriter, r *http.Request) {
-	user, ok := readUser(w, r)
-	if !ok {
-		return
-	}
+type ResultsResponse struct {
+	Finished  bool
+	Standings []yahtzee.Standing
+	Winners   []yahtzee.User
+}
+
+func (h *handler) Results(w http.ResponseWriter, r *http.Request) {
 	gameID, ok := readGameID(w, r)
 	if !ok {
 		return
@@ -366,54 +1079,110 @@ func (h *handler) Lock(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	diceIndex, ok := readDiceIndex(w, r, len(g.Dices))
+	res := &ResultsResponse{
+		Finished:  g.IsOver(),
+		Standings: g.Standings(),
+		Winners:   g.Winners(),
+	}
+
+	if ok := writeJSON(w, r, res); !ok {
+		return
+	}
+
+	log.Print("results returned")
+}
+
+// Analysis compares the decisions of the players with the optimal strategy
+// after the game is over. It's only available for the games with a solver
+// table, and the game is unlocked while the strategy is evaluated.
+func (h *handler) Analysis(w http.ResponseWriter, r *http.Request) {
+	gameID, ok := readGameID(w, r)
 	if !ok {
 		return
 	}
 
-	if len(g.Players) == 0 {
-		writeError(w, r, nil, "no players joined", http.StatusBadRequest)
+	unlocker, err := h.store.Lock(gameID)
+	if err != nil {
+		writeError(w, r, err, "locking issue", http.StatusInternalServerError)
 		return
 	}
-	currentPlayer := g.Players[g.CurrentPlayer]
-	if user != currentPlayer.User {
-		writeError(w, r, nil, "another players turn", http.StatusBadRequest)
+
+	g, err := h.store.Load(gameID)
+	if err != nil {
+		unlocker()
+		writeStoreError(w, r, err)
 		return
 	}
-	if g.Round >= 13 {
-		writeError(w, r, nil, "game is over", http.StatusBadRequest)
+	moves, err := h.store.LoadMoves(gameID)
+	unlocker()
+	if err != nil {
+		writeStoreError(w, r, err)
 		return
 	}
-	if g.RollCount == 0 {
-		writeError(w, r, nil, "roll first", http.StatusBadRequest)
+
+	if !g.IsOver() {
+		writeGameError(w, r, yahtzee.ErrGameNotOver)
 		return
 	}
-	if g.RollCount >= 3 {
-		writeError(w, r, nil, "no more rolls", http.StatusBadRequest)
+	s, err := solver.For(&g)
+	if errors.Is(err, solver.ErrNoTable) {
+		writeError(w, r, err, "analysis unavailable", http.StatusServiceUnavailable)
+		return
+	} else if err != nil {
+		writeValidationError(w, r, err)
 		return
 	}
 
-	g.Dices[diceIndex].Locked = !g.Dices[diceIndex].Locked
-
-	if err := h.store.Save(gameID, g); err != nil {
-		writeStoreError(w, r, err)
+	res, err := analysis.Analyze(&g, moves, s)
+	if err != nil {
+		writeError(w, r, err, "analysis", http.StatusInternalServerError)
 		return
 	}
 
-	changes := &LockResponse{
-		Dices: g.Dices,
+	if ok := writeJSON(w, r, res); !ok {
+		return
 	}
 
-	h.emitter.Emit(gameID, &user, event.Lock, changes)
+	log.Print("analysis returned")
+}
 
-	if ok := writeJSON(w, r, changes); !ok {
-		return
-	}
+type UndoResponse struct {
+	// User made the action to undo
+	User yahtzee.User
 
-	log.Print("toggled dice")
+	// Approvals has the opponents who approved the undo
+	Approvals []yahtzee.User
+
+	// Game is only present when the action is undone
+	Game *GameResponse `json:",omitempty"`
 }
 
-func (h *handler) Score(w http.ResponseWriter, r *http.Request) {
+func (h *handler) RequestUndo(w http.ResponseWriter, r *http.Request) {
+	h.handleUndo(w, r, event.RequestUndo, func(g *yahtzee.Game, u *yahtzee.Undo, user yahtzee.User) error {
+		return u.Request(user)
+	})
+}
+
+func (h *handler) ApproveUndo(w http.ResponseWriter, r *http.Request) {
+	h.handleUndo(w, r, event.ApproveUndo, func(g *yahtzee.Game, u *yahtzee.Undo, user yahtzee.User) error {
+		return u.Approve(g, user)
+	})
+}
+
+func (h *handler) DeclineUndo(w http.ResponseWriter, r *http.Request) {
+	h.handleUndo(w, r, event.DeclineUndo, func(g *yahtzee.Game, u *yahtzee.Undo, user yahtzee.User) error {
+		return u.Decline(g, user)
+	})
+}
+
+// handleUndo applies the `action` of the user on the undo of the game. A
+// declined undo is dropped, and an approved one restores the game.
+func (h *handler) handleUndo(
+	w http.ResponseWriter,
+	r *http.Request,
+	t event.Type,
+	action func(*yahtzee.Game, *yahtzee.Undo, yahtzee.User) error,
+) {
 	user, ok := readUser(w, r)
 	if !ok {
 		return
@@ -422,10 +1191,6 @@ func (h *handler) Score(w http.ResponseWriter, r *http.Request) {
 	if !ok {
 		return
 	}
-	category, ok := readCategory(w, r)
-	if !ok {
-		return
-	}
 
 	unlocker, err := h.store.Lock(gameID)
 	if err != nil {
@@ -440,84 +1205,75 @@ func (h *handler) Score(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if len(g.Players) == 0 {
-		writeError(w, r, nil, "no players joined", http.StatusBadRequest)
-		return
-	}
-	currentPlayer := g.Players[g.CurrentPlayer]
-	if user != currentPlayer.User {
-		writeError(w, r, nil, "another players turn", http.StatusBadRequest)
-		return
-	}
-	if g.Round >= 13 {
-		writeError(w, r, nil, "game is over", http.StatusBadRequest)
-		return
-	}
-	if g.RollCount == 0 {
-		writeError(w, r, nil, "roll first", http.StatusBadRequest)
+	undo, err := h.store.LoadUndo(gameID)
+	if errors.Is(err, store.ErrNotExists) {
+		writeGameError(w, r, yahtzee.ErrNothingToUndo)
 		return
-	}
-	if _, ok := currentPlayer.ScoreSheet[category]; ok {
-		writeError(w, r, nil, "category is already used", http.StatusBadRequest)
+	} else if err != nil {
+		writeStoreError(w, r, err)
 		return
 	}
 
-	if g.HasFeature(yahtzee.Ordered) && yahtzee.Categories()[g.Round] != category {
-		writeError(w, r, nil, "invalid category", http.StatusBadRequest)
+	if err := action(&g, &undo, user); err != nil {
+		writeGameError(w, r, err)
 		return
 	}
 
-	var scorer func(game *yahtzee.Game) int
-	if scorer, ok = g.Scorer.ScoreActions[category]; !ok {
-		writeError(w, r, nil, "invalid category", http.StatusBadRequest)
-		return
+	changes := &UndoResponse{
+		User:      undo.User,
+		Approvals: undo.Approvals,
 	}
 
-	dices := make([]int, len(g.Dices))
-	for i, d := range g.Dices {
-		dices[i] = d.Value
+	switch {
+	case t == event.DeclineUndo:
+		err = h.store.DeleteUndo(gameID)
+	case undo.Approved(&g):
+		g.Restore(&undo)
+		if err := h.store.Save(gameID, g); err != nil {
+			writeStoreError(w, r, err)
+			return
+		}
+		if err := h.removeUndoneScore(gameID, undo.User); err != nil {
+			writeStoreError(w, r, err)
+			return
+		}
+		err = h.store.DeleteUndo(gameID)
+		t = event.Undo
+		changes.Game = gameResponse(&g)
+	default:
+		err = h.store.SaveUndo(gameID, undo)
 	}
-
-	//prescore actions
-	for _, action := range g.Scorer.PreScoreActions {
-		action(&g)
+	if err != nil {
+		writeStoreError(w, r, err)
+		return
 	}
 
-	currentPlayer.ScoreSheet[category] = scorer(&g)
-
-	//postscore actions
-	for _, action := range g.Scorer.PostScoreActions {
-		action(&g)
+	h.emitter.Emit(gameID, &user, t, changes)
+	if t == event.Undo {
+		h.playBots(gameID)
 	}
 
-	for _, d := range g.Dices {
-		d.Locked = false
+	if ok := writeJSON(w, r, changes); !ok {
+		return
 	}
 
-	g.RollCount = 0
-	g.CurrentPlayer = (g.CurrentPlayer + 1) % len(g.Players)
-	if g.CurrentPlayer == 0 {
-		g.Round++
-	}
+	log.Printf("undo %s", t)
+}
 
-	if g.Round >= 13 { //End of game, running postgame actions
-		for _, action := range g.Scorer.PostGameActions {
-			action(&g)
-		}
+// removeUndoneScore drops the last move of the game's history when the undone
+// action was the user's score. The undo of a lock leaves the history as is.
+func (h *handler) removeUndoneScore(gameID string, u yahtzee.User) error {
+	moves, err := h.store.LoadMoves(gameID)
+	if err != nil {
+		return err
 	}
-
-	if err := h.store.Save(gameID, g); err != nil {
-		writeStoreError(w, r, err)
-		return
+	if len(moves) == 0 {
+		return nil
 	}
-
-	h.emitter.Emit(gameID, &user, event.Score, &g)
-
-	if ok := writeJSON(w, r, &g); !ok {
-		return
+	if last := moves[len(moves)-1]; !last.IsScore() || last.User != u {
+		return nil
 	}
-
-	log.Print("scored")
+	return h.store.RemoveLastMove(gameID)
 }
 
 const (
@@ -604,27 +1360,62 @@ func (h *handler) WS(w http.ResponseWriter, r *http.Request) {
 }
 
 func (h *handler) Features(w http.ResponseWriter, r *http.Request) {
-	features := yahtzee.Features()
+	features := yahtzee.DescribeFeatures()
 	if ok := writeJSON(w, r, &features); !ok {
 		return
 	}
 }
 
-func readDiceIndex(w http.ResponseWriter, r *http.Request, diceNum int) (int, bool) {
+// readCreateRequest reads the features and the options of the new game. The
+// body is either the list of the features or a CreateRequest.
+func readCreateRequest(w http.ResponseWriter, r *http.Request) (*CreateRequest, bool) {
+	req := &CreateRequest{
+		Features: []yahtzee.Feature{},
+	}
+	if r.Body == nil {
+		return req, true
+	}
+
+	body, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		writeError(w, r, err, "create game", http.StatusInternalServerError)
+		return nil, false
+	}
+	body = bytes.TrimSpace(body)
+	if len(body) == 0 {
+		return req, true
+	}
+
+	if body[0] == '{' {
+		err = json.Unmarshal(body, req)
+	} else {
+		err = json.Unmarshal(body, &req.Features)
+	}
+	if err != nil {
+		writeError(w, r, err, "create game", http.StatusBadRequest)
+		return nil, false
+	}
+	if req.Features == nil {
+		req.Features = []yahtzee.Feature{}
+	}
+	return req, true
+}
+
+func readDiceIndex(w http.ResponseWriter, r *http.Request) (int, bool) {
 	raw, ok := mux.Vars(r)["dice"]
 	if !ok {
 		writeError(w, r, nil, "no dice index in request", http.StatusInternalServerError)
 		return 0, false
 	}
 	index, err := strconv.Atoi(raw)
-	if err != nil || index < 0 || index > diceNum-1 {
+	if err != nil {
 		writeError(w, r, err, "invalid dice index", http.StatusBadRequest)
 		return index, false
 	}
 	return index, true
 }
 
-func readDices(w http.ResponseWriter, r *http.Request, diceNum int) ([]int, bool) {
+func readDices(w http.ResponseWriter, r *http.Request, diceNum int, faces int) ([]int, bool) {
 	raw := r.URL.Query().Get("dices")
 	rawDices := strings.Split(raw, ",")
 	if len(rawDices) != diceNum {
@@ -634,7 +1425,7 @@ func readDices(w http.ResponseWriter, r *http.Request, diceNum int) ([]int, bool
 	dices := make([]int, diceNum)
 	for i, d := range rawDices {
 		v, err := strconv.Atoi(d)
-		if err != nil || v < 1 || 6 < v {
+		if err != nil || v < 1 || faces < v {
 			writeError(w, r, err, "invalid dice", http.StatusBadRequest)
 			return nil, false
 		}
@@ -649,6 +1440,9 @@ func readFeatures(w http.ResponseWriter, r *http.Request) ([]yahtzee.Feature, bo
 
 	var features []yahtzee.Feature
 	for _, f := range rawFeatures {
+		if f == "" {
+			continue
+		}
 		features = append(features, yahtzee.Feature(f))
 	}
 	return features, true
@@ -667,6 +1461,32 @@ func readCategory(w http.ResponseWriter, r *http.Request) (yahtzee.Category, boo
 	return yahtzee.Category(body), true
 }
 
+func readColumn(w http.ResponseWriter, r *http.Request) (int, bool) {
+	raw := r.URL.Query().Get("column")
+	if raw == "" {
+		return 0, true
+	}
+	column, err := strconv.Atoi(raw)
+	if err != nil || column < 0 {
+		writeError(w, r, err, "invalid column", http.StatusBadRequest)
+		return 0, false
+	}
+	return column, true
+}
+
+func readHandicap(w http.ResponseWriter, r *http.Request) (*yahtzee.Handicap, bool) {
+	raw := r.URL.Query().Get("handicap")
+	if raw == "" {
+		return nil, true
+	}
+	handicap, err := yahtzee.ParseHandicap(raw)
+	if err != nil {
+		writeError(w, r, err, "invalid handicap", http.StatusBadRequest)
+		return nil, false
+	}
+	return &handicap, true
+}
+
 func readGameID(w http.ResponseWriter, r *http.Request) (string, bool) {
 	gameID, ok := mux.Vars(r)["gameID"]
 	if !ok {
@@ -701,179 +1521,26 @@ func writeError(w http.ResponseWriter, r *http.Request, err error, msg string, s
 	http.Error(w, "", status)
 }
 
+// writeValidationError rejects the request with the reason in the body.
+func writeValidationError(w http.ResponseWriter, r *http.Request, err error) {
+	log.Printf("invalid request: %v", err)
+	http.Error(w, err.Error(), http.StatusBadRequest)
+}
+
 func writeStoreError(w http.ResponseWriter, r *http.Request, err error) {
-	if errors.As(err, &store.ErrNotExists) {
+	if errors.Is(err, store.ErrNotExists) {
 		writeError(w, r, err, "not exists", http.StatusNotFound)
 	} else {
 		writeError(w, r, err, "unknown error", http.StatusInternalServerError)
 	}
 }
 
-func score(category yahtzee.Category, dices []int, yahtzeeBonus bool) (int, error) {
-	s := 0
-	switch category {
-	case yahtzee.Ones:
-		for _, d := range dices {
-			if d == 1 {
-				s++
-			}
-		}
-		s = min(s, 5*1)
-	case yahtzee.Twos:
-		for _, d := range dices {
-			if d == 2 {
-				s += 2
-			}
-		}
-		s = min(s, 5*2)
-	case yahtzee.Threes:
-		for _, d := range dices {
-			if d == 3 {
-				s += 3
-			}
-		}
-		s = min(s, 5*3)
-	case yahtzee.Fours:
-		for _, d := range dices {
-			if d == 4 {
-				s += 4
-			}
-		}
-		s = min(s, 5*4)
-	case yahtzee.Fives:
-		for _, d := range dices {
-			if d == 5 {
-				s += 5
-			}
-		}
-		s = min(s, 5*5)
-	case yahtzee.Sixes:
-		for _, d := range dices {
-			if d == 6 {
-				s += 6
-			}
-		}
-		s = min(s, 5*6)
-	case yahtzee.ThreeOfAKind:
-		occurrences := map[int]int{}
-		for _, d := range dices {
-			occurrences[d]++
-		}
-
-		for k, v := range occurrences {
-			if v >= 3 {
-				s = max(s, 3*k)
-			}
-		}
-	case yahtzee.FourOfAKind:
-		occurrences := map[int]int{}
-		for _, d := range dices {
-			occurrences[d]++
-		}
-
-		for k, v := range occurrences {
-			if v >= 4 {
-				s = 4 * k
-			}
-		}
-	case yahtzee.FullHouse:
-		if score, _ := score(yahtzee.Yahtzee, dices, false); score == 50 && yahtzeeBonus {
-			s = 25
-			break
-		}
-
-		occurrences := map[int]int{}
-		for _, d := range dices {
-			occurrences[d]++
-		}
-
-		three := false
-		two := false
-		for _, v := range occurrences {
-			if !three && v >= 3 {
-				three = true
-				continue
-			}
-			if !two && v >= 2 {
-				two = true
-				continue
-			}
-		}
-
-		if three && two {
-			s = 25
-		}
-	case yahtzee.SmallStraight:
-		if score, _ := score(yahtzee.Yahtzee, dices, false); score == 50 && yahtzeeBonus {
-			s = 30
-			break
-		}
-
-		hit := [6]bool{}
-		for _, d := range dices {
-			hit[d-1] = true
-		}
-
-		if (hit[0] && hit[1] && hit[2] && hit[3]) ||
-			(hit[1] && hit[2] && hit[3] && hit[4]) ||
-			(hit[2] && hit[3] && hit[4] && hit[5]) {
-			s = 30
-		}
-	case yahtzee.LargeStraight:
-		if score, _ := score(yahtzee.Yahtzee, dices, false); score == 50 && yahtzeeBonus {
-			s = 40
-			break
-		}
-		hit := [6]bool{}
-		for _, d := range dices {
-			hit[d-1] = true
-		}
-
-		if (hit[0] && hit[1] && hit[2] && hit[3] && hit[4]) ||
-			(hit[1] && hit[2] && hit[3] && hit[4] && hit[5]) {
-			s = 40
-		}
-	case yahtzee.Yahtzee:
-		for i := 1; i < 7; i++ {
-			sameCount := 0
-			for j := 0; j < len(dices); j++ {
-				if dices[j] == i {
-					sameCount++
-				}
-			}
-			if sameCount >= 5 {
-				s = 50
-				break
-			}
-		}
-	case yahtzee.Chance:
-		for i := 0; i < len(dices); i++ {
-			sum := 0
-			for j, d := range dices {
-				if len(dices) > 5 && j == i {
-					continue
-				}
-				sum += d
-			}
-			s = max(s, sum)
-		}
-	default:
-		return 0, errors.New("invalid category")
-	}
-
-	return s, nil
-}
-
-func min(a, b int) int {
-	if a < b {
-		return a
-	}
-	return b
-}
-
-func max(a, b int) int {
-	if a > b {
-		return a
+func writeGameError(w http.ResponseWriter, r *http.Request, err error) {
+	if errors.Is(err, yahtzee.ErrAlreadyJoined) {
+		writeError(w, r, err, "invalid action", http.StatusConflict)
+	} else if errors.Is(err, yahtzee.ErrNotCreator) {
+		writeError(w, r, err, "invalid action", http.StatusForbidden)
+	} else {
+		writeError(w, r, err, "invalid action", http.StatusBadRequest)
 	}
-	return b
 }
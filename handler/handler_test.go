@@ -14,9 +14,11 @@ import (
 	"github.com/stretchr/testify/suite"
 
 	"github.com/akarasz/yahtzee"
+	"github.com/akarasz/yahtzee/analysis"
 	"github.com/akarasz/yahtzee/event"
 	event_impl "github.com/akarasz/yahtzee/event/embedded"
 	"github.com/akarasz/yahtzee/handler"
+	"github.com/akarasz/yahtzee/solver"
 	store "github.com/akarasz/yahtzee/store/embedded"
 )
 
@@ -30,6 +32,13 @@ type testSuite struct {
 }
 
 func TestSuite(t *testing.T) {
+	// the single round games are solved quickly for the optimal bots
+	short, err := solver.New(yahtzee.GameOptions{Rounds: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	solver.Register(short)
+
 	s := store.New()
 	e := event_impl.New()
 
@@ -100,6 +109,138 @@ func (ts *testSuite) TestCreateTheChance() {
 	}
 }
 
+func (ts *testSuite) TestCreateWithOptions() {
+	badInputs := []struct {
+		description string
+		body        string
+	}{
+		{"not json", `{"Features":`},
+		{"too few dices", `{"Options":{"Dices":4}}`},
+		{"too few dices for maxi", `{"Features":["maxi"],"Options":{"Dices":5}}`},
+		{"too few dices for six-dice", `{"Features":["six-dice"],"Options":{"Dices":5}}`},
+		{"too many dices", `{"Options":{"Dices":11}}`},
+		{"negative rolls", `{"Options":{"RollsPerTurn":-1}}`},
+		{"too many rounds", `{"Options":{"Rounds":14}}`},
+		{"too few faces", `{"Options":{"Faces":2}}`},
+		{"too many rounds for d8", `{"Options":{"Faces":8,"Rounds":16}}`},
+		{"no large straight with d4", `{"Options":{"Faces":4}}`},
+		{"no big straight with d4", `{"Features":["yacht"],"Options":{"Faces":4}}`},
+		{"no escalera with d4", `{"Features":["generala"],"Options":{"Faces":4}}`},
+		{"no full straight with d4", `{"Features":["maxi"],"Options":{"Faces":4}}`},
+	}
+	for _, tc := range badInputs {
+		rr := ts.record(request("POST", "/", tc.body))
+		ts.Exactly(http.StatusBadRequest, rr.Code, "when %s", tc.description)
+	}
+
+	rr := ts.record(request("POST", "/", `{"Features":["yahtzee-bonus"],"Options":{"Rounds":10,"RollsPerTurn":4,"Dices":7}}`))
+	ts.Exactly(http.StatusCreated, rr.Code)
+	if ts.Contains(rr.HeaderMap, "Location") && ts.Len(rr.HeaderMap["Location"], 1) {
+		created := ts.fromStore(strings.TrimLeft(rr.HeaderMap["Location"][0], "/"))
+		ts.Exactly([]yahtzee.Feature{yahtzee.YahtzeeBonus}, created.Features)
+		ts.Exactly(&yahtzee.GameOptions{Rounds: 10, RollsPerTurn: 4, Dices: 7}, created.Options)
+		ts.Len(created.Dices, 7)
+		ts.Exactly(10, created.Rounds())
+		ts.Exactly(4, created.RollsPerTurn())
+	}
+
+	rr = ts.record(request("POST", "/", `{"Features":["six-dice"]}`))
+	ts.Exactly(http.StatusCreated, rr.Code)
+	if ts.Contains(rr.HeaderMap, "Location") && ts.Len(rr.HeaderMap["Location"], 1) {
+		created := ts.fromStore(strings.TrimLeft(rr.HeaderMap["Location"][0], "/"))
+		ts.Exactly([]yahtzee.Feature{yahtzee.SixDice}, created.Features)
+		ts.Nil(created.Options)
+		ts.Len(created.Dices, 6)
+	}
+}
+
+func (ts *testSuite) TestPlayWithOptions() {
+	g := yahtzee.NewGameWithOptions(yahtzee.GameOptions{Rounds: 1, RollsPerTurn: 4, Dices: 7})
+	g.Players = []*yahtzee.Player{
+		yahtzee.NewPlayer("Alice"),
+	}
+	ts.Require().NoError(ts.store.Save("optionsID", *g))
+
+	for i := 0; i < 4; i++ {
+		rr := ts.record(request("POST", "/optionsID/roll"), asUser("Alice"))
+		ts.Exactly(http.StatusOK, rr.Code)
+	}
+	rr := ts.record(request("POST", "/optionsID/roll"), asUser("Alice"))
+	ts.Exactly(http.StatusBadRequest, rr.Code)
+
+	rr = ts.record(request("POST", "/optionsID/lock/6"), asUser("Alice"))
+	ts.Exactly(http.StatusBadRequest, rr.Code)
+
+	saved := ts.fromStore("optionsID")
+	values := []int{6, 1, 5, 2, 4, 3, 6}
+	for i, d := range saved.Dices {
+		d.Value = values[i]
+	}
+	saved.RollCount = 3
+	ts.Require().NoError(ts.store.Save("optionsID", *saved))
+
+	rr = ts.record(request("POST", "/optionsID/lock/6"), asUser("Alice"))
+	ts.Exactly(http.StatusOK, rr.Code)
+
+	rr = ts.record(request("GET", "/optionsID/hints"))
+	ts.Exactly(http.StatusOK, rr.Code)
+	var hints map[yahtzee.Category]int
+	ts.Require().NoError(json.Unmarshal(rr.Body.Bytes(), &hints))
+	ts.Exactly(24, hints[yahtzee.Chance])
+	ts.Exactly(40, hints[yahtzee.LargeStraight])
+
+	rr = ts.record(request("POST", "/optionsID/score", "chance"), asUser("Alice"))
+	ts.Exactly(http.StatusOK, rr.Code)
+
+	saved = ts.fromStore("optionsID")
+	ts.True(saved.IsOver())
+	ts.Exactly(24, saved.Players[0].ScoreSheet[yahtzee.Chance])
+}
+
+func (ts *testSuite) TestPlayWithFaces() {
+	g := yahtzee.NewGameWithOptions(yahtzee.GameOptions{Faces: 8})
+	g.Players = []*yahtzee.Player{
+		yahtzee.NewPlayer("Alice"),
+	}
+	g.Players[0].ScoreSheet = map[yahtzee.Category]int{
+		yahtzee.Ones:   3,
+		yahtzee.Twos:   6,
+		yahtzee.Threes: 9,
+		yahtzee.Fours:  12,
+		yahtzee.Fives:  15,
+		yahtzee.Sixes:  18,
+		yahtzee.Sevens: 21,
+	}
+	g.Round = 7
+	roller := &scriptedRoller{values: []int{8, 8, 8, 1, 2}}
+	g.Roller = roller
+	ts.Require().NoError(ts.store.Save("facesID", *g))
+
+	rr := ts.record(request("POST", "/facesID/roll"), asUser("Alice"))
+	ts.Exactly(http.StatusOK, rr.Code)
+	ts.Exactly([]int{8, 8, 8, 8, 8}, roller.faces)
+
+	rr = ts.record(request("POST", "/facesID/score", "nines"), asUser("Alice"))
+	ts.Exactly(http.StatusBadRequest, rr.Code)
+
+	rr = ts.record(request("POST", "/facesID/score", "eights"), asUser("Alice"))
+	ts.Exactly(http.StatusOK, rr.Code)
+
+	saved := ts.fromStore("facesID")
+	ts.Exactly(15, saved.Rounds())
+	ts.Exactly(map[yahtzee.Category]int{
+		yahtzee.Ones:   3,
+		yahtzee.Twos:   6,
+		yahtzee.Threes: 9,
+		yahtzee.Fours:  12,
+		yahtzee.Fives:  15,
+		yahtzee.Sixes:  18,
+		yahtzee.Sevens: 21,
+		yahtzee.Eights: 24,
+		yahtzee.Bonus:  35,
+	}, saved.Players[0].ScoreSheet)
+}
+
 func (ts *testSuite) TestHints() {
 	badInputs := []struct {
 		description string
@@ -137,6 +278,230 @@ func (ts *testSuite) TestHints() {
 		}`, rr.Body.String())
 }
 
+func (ts *testSuite) TestHintsForDices() {
+	badInputs := []struct {
+		description string
+		body        string
+	}{
+		{"no body", ""},
+		{"not json", "wat"},
+		{"no dices", `{}`},
+		{"too few dices", `{"Dices":[1,2,3,4]}`},
+		{"too many dices", `{"Dices":[1,2,3,4,5,6]}`},
+		{"too few six dices", `{"Dices":[1,2,3,4,5],"Features":["six-dice"]}`},
+		{"has low face value", `{"Dices":[1,1,1,0,1]}`},
+		{"has high face value", `{"Dices":[7,6,6,6,6]}`},
+		{"invalid options", `{"Dices":[1,2,3,4],"Options":{"Dices":4}}`},
+		{"dices not matching options", `{"Dices":[1,2,3,4,5],"Options":{"Dices":7}}`},
+		{"odd faces", `{"Dices":[1,2,3,4,5],"Options":{"Faces":7}}`},
+		{"too many faces", `{"Dices":[1,2,3,4,5],"Options":{"Faces":20}}`},
+		{"has higher face value than d8", `{"Dices":[9,8,8,8,8],"Options":{"Faces":8}}`},
+		{"no large straight with d4", `{"Dices":[4,4,3,2,1],"Options":{"Faces":4}}`},
+	}
+	for _, tc := range badInputs {
+		rr := ts.record(request("POST", "/score", tc.body))
+		ts.Exactly(http.StatusBadRequest, rr.Code, "when %s", tc.description)
+	}
+
+	inputs := []struct {
+		body     string
+		response string
+	}{
+		{`{"Dices":[3,2,6,4,5]}`, `{
+			"ones":0,
+			"twos":2,
+			"threes":3,
+			"fours":4,
+			"fives":5,
+			"sixes":6,
+			"three-of-a-kind":0,
+			"four-of-a-kind":0,
+			"full-house":0,
+			"small-straight":30,
+			"large-straight":40,
+			"yahtzee":0,
+			"chance":20
+		}`},
+		{`{"Dices":[5,5,5,5,5],"Features":["yahtzee-bonus"]}`, `{
+			"ones":0,
+			"twos":0,
+			"threes":0,
+			"fours":0,
+			"fives":25,
+			"sixes":0,
+			"three-of-a-kind":15,
+			"four-of-a-kind":20,
+			"full-house":0,
+			"small-straight":0,
+			"large-straight":0,
+			"yahtzee":50,
+			"chance":25
+		}`},
+		{`{"Dices":[5,5,5,5,5],"Features":["official"],"ScoreSheet":{"yahtzee":50}}`, `{
+			"ones":0,
+			"twos":0,
+			"threes":0,
+			"fours":0,
+			"fives":25,
+			"sixes":0,
+			"three-of-a-kind":0,
+			"four-of-a-kind":0,
+			"full-house":0,
+			"small-straight":0,
+			"large-straight":0,
+			"yahtzee":0,
+			"chance":0
+		}`},
+		{`{"Dices":[5,5,5,5,5],"Features":["official"],"ScoreSheet":{"yahtzee":50,"fives":20}}`, `{
+			"ones":0,
+			"twos":0,
+			"threes":0,
+			"fours":0,
+			"fives":0,
+			"sixes":0,
+			"three-of-a-kind":25,
+			"four-of-a-kind":25,
+			"full-house":25,
+			"small-straight":30,
+			"large-straight":40,
+			"yahtzee":0,
+			"chance":25
+		}`},
+		{`{"Dices":[5,5,5,5,5],"Features":["official"],"ScoreSheet":{"yahtzee":0,"fives":20,"three-of-a-kind":20,"four-of-a-kind":0,"full-house":25,"small-straight":30,"large-straight":0,"chance":22}}`, `{
+			"ones":0,
+			"twos":0,
+			"threes":0,
+			"fours":0,
+			"fives":0,
+			"sixes":0,
+			"three-of-a-kind":0,
+			"four-of-a-kind":0,
+			"full-house":0,
+			"small-straight":0,
+			"large-straight":0,
+			"yahtzee":0,
+			"chance":0
+		}`},
+		{`{"Dices":[3,3,3,2,2],"Features":["ordered"],"ScoreSheet":{"ones":2,"twos":4}}`, `{
+			"ones":0,
+			"twos":0,
+			"threes":9,
+			"fours":0,
+			"fives":0,
+			"sixes":0,
+			"three-of-a-kind":0,
+			"four-of-a-kind":0,
+			"full-house":0,
+			"small-straight":0,
+			"large-straight":0,
+			"yahtzee":0,
+			"chance":0
+		}`},
+		{`{"Dices":[8,4,7,5,6],"Options":{"Faces":8}}`, `{
+			"ones":0,
+			"twos":0,
+			"threes":0,
+			"fours":4,
+			"fives":5,
+			"sixes":6,
+			"sevens":7,
+			"eights":8,
+			"three-of-a-kind":0,
+			"four-of-a-kind":0,
+			"full-house":0,
+			"small-straight":30,
+			"large-straight":40,
+			"yahtzee":0,
+			"chance":30
+		}`},
+		{`{"Dices":[8,7,6,5,3],"Options":{"Faces":8}}`, `{
+			"ones":0,
+			"twos":0,
+			"threes":3,
+			"fours":0,
+			"fives":5,
+			"sixes":6,
+			"sevens":7,
+			"eights":8,
+			"three-of-a-kind":0,
+			"four-of-a-kind":0,
+			"full-house":0,
+			"small-straight":30,
+			"large-straight":0,
+			"yahtzee":0,
+			"chance":29
+		}`},
+		{`{"Dices":[12,9,10,8,11],"Features":["yacht"],"Options":{"Faces":12}}`, `{
+			"ones":0,
+			"twos":0,
+			"threes":0,
+			"fours":0,
+			"fives":0,
+			"sixes":0,
+			"sevens":0,
+			"eights":8,
+			"nines":9,
+			"tens":10,
+			"elevens":11,
+			"twelves":12,
+			"full-house":0,
+			"four-of-a-kind":0,
+			"little-straight":0,
+			"big-straight":30,
+			"choice":50,
+			"yacht":0
+		}`},
+		{`{"Dices":[6,5,5,5,5,5,6],"Options":{"Dices":7}}`, `{
+			"ones":0,
+			"twos":0,
+			"threes":0,
+			"fours":0,
+			"fives":25,
+			"sixes":12,
+			"three-of-a-kind":15,
+			"four-of-a-kind":20,
+			"full-house":25,
+			"small-straight":0,
+			"large-straight":0,
+			"yahtzee":50,
+			"chance":27
+		}`},
+		{`{"Dices":[6,5,5,5,5,5],"Features":["six-dice"]}`, `{
+			"ones":0,
+			"twos":0,
+			"threes":0,
+			"fours":0,
+			"fives":25,
+			"sixes":6,
+			"three-of-a-kind":15,
+			"four-of-a-kind":20,
+			"full-house":0,
+			"small-straight":0,
+			"large-straight":0,
+			"yahtzee":50,
+			"chance":26
+		}`},
+	}
+	for _, tc := range inputs {
+		rr := ts.record(request("POST", "/score", tc.body))
+		ts.Exactly(http.StatusOK, rr.Code)
+		ts.JSONEq(tc.response, rr.Body.String(), "when %s", tc.body)
+	}
+
+	// allowed categories
+	rr := ts.record(request("POST", "/score", `{"Dices":[5,5,5,5,5],"Features":["official"],"ScoreSheet":{"yahtzee":50}}`),
+		withQuery("allowed", "true"))
+	ts.Exactly(http.StatusOK, rr.Code)
+	var got handler.HintsResponse
+	ts.Require().NoError(json.Unmarshal(rr.Body.Bytes(), &got))
+	ts.Exactly(25, got.Points[yahtzee.Fives])
+	ts.Exactly(0, got.Points[yahtzee.Chance])
+	ts.True(got.Allowed[yahtzee.Fives])
+	ts.False(got.Allowed[yahtzee.Chance])
+	ts.Contains(got.Allowed, yahtzee.Chance)
+	ts.Len(got.Allowed, 13)
+}
+
 func (ts *testSuite) TestHintsForGame() {
 	inputs := []struct {
 		features []yahtzee.Feature
@@ -311,46 +676,272 @@ func (ts *testSuite) TestHintsForGame() {
 	}
 }
 
-func (ts *testSuite) TestHintsForGameOrdered() {
-	inputs := []struct {
-		dices    []int
-		round    int
-		response string
-	}{
-		{[]int{3, 2, 6, 4, 5}, 0, `{
-			"ones":0,
-			"twos":0,
-			"threes":0,
-			"fours":0,
-			"fives":0,
-			"sixes":0,
-			"three-of-a-kind":0,
-			"four-of-a-kind":0,
-			"full-house":0,
-			"small-straight":0,
-			"large-straight":0,
-			"yahtzee":0,
-			"chance":0
-		}`},
-		{[]int{5, 5, 5, 5, 5}, 12, `{
-			"ones":0,
-			"twos":0,
-			"threes":0,
-			"fours":0,
-			"fives":0,
-			"sixes":0,
-			"three-of-a-kind":0,
-			"four-of-a-kind":0,
-			"full-house":0,
-			"small-straight":0,
-			"large-straight":0,
-			"yahtzee":0,
-			"chance":25
-		}`},
-		{[]int{6, 6, 6, 6, 6}, 13, `{
-			"ones":0,
-			"twos":0,
-			"threes":0,
+func (ts *testSuite) TestHintsForGameExpected() {
+	g := yahtzee.NewGame()
+	g.Players = []*yahtzee.Player{
+		yahtzee.NewPlayer("Alice"),
+	}
+	g.RollCount = 1
+	for i, d := range g.Dices {
+		d.Value = []int{6, 6, 2, 6, 6}[i]
+	}
+	ts.Require().NoError(ts.store.Save("expectedID", *g))
+
+	var got handler.ExpectedHintsResponse
+	rr := ts.record(request("GET", "/expectedID/hints"), withQuery("expected", "true"))
+	ts.Require().Exactly(http.StatusOK, rr.Code)
+	ts.Require().NoError(json.Unmarshal(rr.Body.Bytes(), &got))
+
+	ts.Exactly(24, got.Points[yahtzee.Sixes])
+	ts.Exactly([]int{0, 1, 3, 4}, got.Hold)
+	ts.InDelta(50*(1-25.0/36), got.Expected[yahtzee.Yahtzee], 0.001)
+	ts.InDelta(25.0/36*(24+35*24/63.0)+11.0/36*(30+35*30/63.0), got.Expected[yahtzee.Sixes], 0.001)
+	ts.Len(got.Expected, 13)
+	ts.True(got.Value > got.Expected[yahtzee.Yahtzee])
+
+	// upper section bonus reached
+	g.Players[0].ScoreSheet = map[yahtzee.Category]int{
+		yahtzee.Ones:   3,
+		yahtzee.Twos:   6,
+		yahtzee.Threes: 9,
+		yahtzee.Fours:  12,
+		yahtzee.Fives:  15,
+	}
+	g.RollCount = 3
+	ts.Require().NoError(ts.store.Save("expectedID", *g))
+
+	got = handler.ExpectedHintsResponse{}
+	rr = ts.record(request("GET", "/expectedID/hints"), withQuery("expected", "true"))
+	ts.Require().Exactly(http.StatusOK, rr.Code)
+	ts.Require().NoError(json.Unmarshal(rr.Body.Bytes(), &got))
+
+	ts.Empty(got.Hold)
+	ts.InDelta(24+35, got.Expected[yahtzee.Sixes], 0.001)
+	ts.InDelta(26, got.Expected[yahtzee.Chance], 0.001)
+	ts.NotContains(got.Expected, yahtzee.Ones)
+	ts.InDelta(59, got.Value, 0.001)
+
+	// not rolled yet
+	g.Players[0].ScoreSheet = map[yahtzee.Category]int{}
+	g.RollCount = 0
+	ts.Require().NoError(ts.store.Save("expectedID", *g))
+
+	got = handler.ExpectedHintsResponse{}
+	rr = ts.record(request("GET", "/expectedID/hints"), withQuery("expected", "true"))
+	ts.Require().Exactly(http.StatusOK, rr.Code)
+	ts.Require().NoError(json.Unmarshal(rr.Body.Bytes(), &got))
+
+	ts.Empty(got.Hold)
+	ts.InDelta(23.33, got.Expected[yahtzee.Chance], 0.01)
+	ts.InDelta(50*0.046, got.Expected[yahtzee.Yahtzee], 0.01)
+
+	// served combinations are worth more only when scored after the first roll
+	g = yahtzee.NewGame(yahtzee.GeneralaRules)
+	g.Players = []*yahtzee.Player{
+		yahtzee.NewPlayer("Alice"),
+	}
+	g.RollCount = 1
+	for i, d := range g.Dices {
+		d.Value = []int{5, 5, 2, 5, 2}[i]
+	}
+	ts.Require().NoError(ts.store.Save("expectedID", *g))
+
+	got = handler.ExpectedHintsResponse{}
+	rr = ts.record(request("GET", "/expectedID/hints"), withQuery("expected", "true"))
+	ts.Require().Exactly(http.StatusOK, rr.Code)
+	ts.Require().NoError(json.Unmarshal(rr.Body.Bytes(), &got))
+	ts.InDelta(35, got.Expected[yahtzee.Full], 0.001)
+
+	// the later rolls are not served: the full is worth 30 instead of the 25
+	// of the default rules
+	expected := func(features ...yahtzee.Feature) map[yahtzee.Category]float64 {
+		g := yahtzee.NewGame(features...)
+		g.Players = []*yahtzee.Player{
+			yahtzee.NewPlayer("Alice"),
+		}
+		g.RollCount = 1
+		for i, d := range g.Dices {
+			d.Value = []int{5, 5, 5, 2, 3}[i]
+		}
+		ts.Require().NoError(ts.store.Save("expectedID", *g))
+
+		got := handler.ExpectedHintsResponse{}
+		rr := ts.record(request("GET", "/expectedID/hints"), withQuery("expected", "true"))
+		ts.Require().Exactly(http.StatusOK, rr.Code)
+		ts.Require().NoError(json.Unmarshal(rr.Body.Bytes(), &got))
+		return got.Expected
+	}
+	ts.InDelta(expected()[yahtzee.FullHouse]*30/25, expected(yahtzee.GeneralaRules)[yahtzee.Full], 0.001)
+
+	// no players
+	ts.Require().NoError(ts.store.Save("expectedID", *yahtzee.NewGame()))
+
+	rr = ts.record(request("GET", "/expectedID/hints"), withQuery("expected", "true"))
+	ts.Exactly(http.StatusBadRequest, rr.Code)
+
+	// game over
+	g = yahtzee.NewGame(yahtzee.Ordered)
+	g.Players = []*yahtzee.Player{
+		yahtzee.NewPlayer("Alice"),
+	}
+	g.Round = 13
+	ts.Require().NoError(ts.store.Save("expectedID", *g))
+
+	rr = ts.record(request("GET", "/expectedID/hints"), withQuery("expected", "true"))
+	ts.Exactly(http.StatusBadRequest, rr.Code)
+
+	// too many dices
+	g = yahtzee.NewGameWithOptions(yahtzee.GameOptions{Dices: 10, Faces: 12})
+	g.Players = []*yahtzee.Player{
+		yahtzee.NewPlayer("Alice"),
+	}
+	g.RollCount = 1
+	ts.Require().NoError(ts.store.Save("expectedID", *g))
+
+	rr = ts.record(request("GET", "/expectedID/hints"), withQuery("expected", "true"))
+	ts.Exactly(http.StatusBadRequest, rr.Code)
+}
+
+func (ts *testSuite) TestOdds() {
+	rr := ts.record(request("GET", "/oddsID/odds"))
+	ts.Exactly(http.StatusNotFound, rr.Code)
+
+	g := yahtzee.NewGame()
+	g.Players = []*yahtzee.Player{
+		yahtzee.NewPlayer("Alice"),
+	}
+	g.RollCount = 2
+	for i, d := range g.Dices {
+		d.Value = []int{6, 6, 6, 2, 3}[i]
+		d.Locked = i < 3
+	}
+	ts.Require().NoError(ts.store.Save("oddsID", *g))
+
+	var got map[yahtzee.Category]*yahtzee.Odds
+	rr = ts.record(request("GET", "/oddsID/odds"))
+	ts.Require().Exactly(http.StatusOK, rr.Code)
+	ts.Require().NoError(json.Unmarshal(rr.Body.Bytes(), &got))
+
+	ts.Len(got, 13)
+	ts.InDelta(1.0/36, got[yahtzee.Yahtzee].Probability, 0.000001)
+	ts.InDelta(50.0/36, got[yahtzee.Yahtzee].Expected, 0.000001)
+	ts.InDelta(35.0/36, got[yahtzee.Yahtzee].Distribution[0], 0.000001)
+	ts.InDelta(1.0/36, got[yahtzee.Yahtzee].Distribution[50], 0.000001)
+	ts.InDelta(1, got[yahtzee.Sixes].Probability, 0.000001)
+	ts.InDelta(20, got[yahtzee.Sixes].Expected, 0.000001)
+	ts.InDelta(25.0/36, got[yahtzee.Sixes].Distribution[18], 0.000001)
+	ts.InDelta(10.0/36, got[yahtzee.Sixes].Distribution[24], 0.000001)
+	ts.InDelta(25, got[yahtzee.Chance].Expected, 0.000001)
+	ts.InDelta(11.0/36, got[yahtzee.FourOfAKind].Probability, 0.000001)
+
+	// not rolled yet
+	g.RollCount = 0
+	ts.Require().NoError(ts.store.Save("oddsID", *g))
+
+	got = nil
+	rr = ts.record(request("GET", "/oddsID/odds"))
+	ts.Require().Exactly(http.StatusOK, rr.Code)
+	ts.Require().NoError(json.Unmarshal(rr.Body.Bytes(), &got))
+
+	ts.InDelta(0.04603, got[yahtzee.Yahtzee].Probability, 0.00001)
+	ts.InDelta(1, got[yahtzee.Chance].Probability, 0.000001)
+
+	// the served combinations are scored after the first roll only
+	g = yahtzee.NewGame(yahtzee.GeneralaRules)
+	g.Players = []*yahtzee.Player{
+		yahtzee.NewPlayer("Alice"),
+	}
+	g.RollCount = 1
+	for i, d := range g.Dices {
+		d.Value = []int{5, 5, 5, 2, 3}[i]
+		d.Locked = i < 3
+	}
+	ts.Require().NoError(ts.store.Save("oddsID", *g))
+
+	got = nil
+	rr = ts.record(request("GET", "/oddsID/odds"))
+	ts.Require().Exactly(http.StatusOK, rr.Code)
+	ts.Require().NoError(json.Unmarshal(rr.Body.Bytes(), &got))
+	ts.NotContains(got[yahtzee.Full].Distribution, 35)
+	ts.Contains(got[yahtzee.Full].Distribution, 30)
+
+	// no players
+	ts.Require().NoError(ts.store.Save("oddsID", *yahtzee.NewGame()))
+
+	rr = ts.record(request("GET", "/oddsID/odds"))
+	ts.Exactly(http.StatusBadRequest, rr.Code)
+
+	// game over
+	g = yahtzee.NewGame(yahtzee.Ordered)
+	g.Players = []*yahtzee.Player{
+		yahtzee.NewPlayer("Alice"),
+	}
+	g.Round = 13
+	ts.Require().NoError(ts.store.Save("oddsID", *g))
+
+	rr = ts.record(request("GET", "/oddsID/odds"))
+	ts.Exactly(http.StatusBadRequest, rr.Code)
+
+	// any dices
+	rr = ts.record(request("POST", "/odds", `{"Dices": [6,6,6,2,3], "RollCount": 3}`))
+	ts.Require().Exactly(http.StatusOK, rr.Code)
+	got = nil
+	ts.Require().NoError(json.Unmarshal(rr.Body.Bytes(), &got))
+	ts.Exactly(0.0, got[yahtzee.Yahtzee].Probability)
+	ts.Exactly(map[int]float64{18: 1}, got[yahtzee.Sixes].Distribution)
+
+	rr = ts.record(request("POST", "/odds", `{"Dices": [6,6,6,6,6,1], "Features": ["six-dice"], "Locked": [0,1,2,3,4]}`))
+	ts.Require().Exactly(http.StatusOK, rr.Code)
+	got = nil
+	ts.Require().NoError(json.Unmarshal(rr.Body.Bytes(), &got))
+	ts.Len(got[yahtzee.Yahtzee].Distribution, 1)
+	ts.InDelta(1, got[yahtzee.Yahtzee].Distribution[50], 0.000001)
+	ts.InDelta(30, got[yahtzee.Chance].Expected, 0.000001)
+
+	rr = ts.record(request("POST", "/odds", `{"Dices": [6,6,6,2,3], "Locked": [5]}`))
+	ts.Exactly(http.StatusBadRequest, rr.Code)
+}
+
+func (ts *testSuite) TestHintsForGameOrdered() {
+	inputs := []struct {
+		dices    []int
+		round    int
+		response string
+	}{
+		{[]int{3, 2, 6, 4, 5}, 0, `{
+			"ones":0,
+			"twos":0,
+			"threes":0,
+			"fours":0,
+			"fives":0,
+			"sixes":0,
+			"three-of-a-kind":0,
+			"four-of-a-kind":0,
+			"full-house":0,
+			"small-straight":0,
+			"large-straight":0,
+			"yahtzee":0,
+			"chance":0
+		}`},
+		{[]int{5, 5, 5, 5, 5}, 12, `{
+			"ones":0,
+			"twos":0,
+			"threes":0,
+			"fours":0,
+			"fives":0,
+			"sixes":0,
+			"three-of-a-kind":0,
+			"four-of-a-kind":0,
+			"full-house":0,
+			"small-straight":0,
+			"large-straight":0,
+			"yahtzee":0,
+			"chance":25
+		}`},
+		{[]int{6, 6, 6, 6, 6}, 13, `{
+			"ones":0,
+			"twos":0,
+			"threes":0,
 			"fours":0,
 			"fives":0,
 			"sixes":30,
@@ -777,6 +1368,32 @@ func (ts *testSuite) TestGet() {
 		"RollCount": 1,
 		"Features":["six-dice"]
 	}`, rr.Body.String())
+
+	// the seed is secret until the game is over
+	seeded := yahtzee.NewGameWithOptions(yahtzee.GameOptions{Rounds: 1})
+	seeded.Players = []*yahtzee.Player{
+		yahtzee.NewPlayer("Alice"),
+	}
+	seeded.Seed = 42
+	seeded.RollCounter = 5
+	ts.Require().NoError(ts.store.Save("getID", *seeded))
+
+	var got map[string]interface{}
+	rr = ts.record(request("GET", "/getID"))
+	ts.Require().Exactly(http.StatusOK, rr.Code)
+	ts.Require().NoError(json.Unmarshal(rr.Body.Bytes(), &got))
+	ts.NotContains(got, "Seed")
+	ts.NotContains(got, "RollCounter")
+
+	seeded.Round = 1
+	ts.Require().NoError(ts.store.Save("getID", *seeded))
+
+	got = nil
+	rr = ts.record(request("GET", "/getID"))
+	ts.Require().Exactly(http.StatusOK, rr.Code)
+	ts.Require().NoError(json.Unmarshal(rr.Body.Bytes(), &got))
+	ts.Exactly(42.0, got["Seed"])
+	ts.Exactly(5.0, got["RollCounter"])
 }
 
 func (ts *testSuite) TestAddPlayer() {
@@ -949,6 +1566,114 @@ func (ts *testSuite) TestRollingALot() {
 	}
 }
 
+func (ts *testSuite) TestRollScripted() {
+	g := yahtzee.NewGame()
+	g.Players = []*yahtzee.Player{
+		yahtzee.NewPlayer("Alice"),
+	}
+	g.Roller = &scriptedRoller{values: []int{6, 5, 4, 3, 2, 1, 1}}
+	g.Dices[2].Locked = true
+	ts.Require().NoError(ts.store.Save("rollScriptedID", *g))
+
+	rr := ts.record(request("POST", "/rollScriptedID/roll"), asUser("Alice"))
+	ts.Exactly(http.StatusOK, rr.Code)
+	ts.JSONEq(`{
+		"Dices": [
+			{"Value": 6, "Locked": false},
+			{"Value": 5, "Locked": false},
+			{"Value": 1, "Locked": true},
+			{"Value": 4, "Locked": false},
+			{"Value": 3, "Locked": false}
+		],
+		"RollCount": 1
+	}`, rr.Body.String())
+
+	saved := ts.fromStore("rollScriptedID")
+	ts.Exactly(4, saved.RollCounter)
+}
+
+func (ts *testSuite) TestRollSeeded() {
+	rollWithSeed := func(id string, seed int64) *yahtzee.Game {
+		g := yahtzee.NewGame()
+		g.Players = []*yahtzee.Player{
+			yahtzee.NewPlayer("Alice"),
+		}
+		g.Seed = seed
+		ts.Require().NoError(ts.store.Save(id, *g))
+
+		ts.record(request("POST", "/"+id+"/roll"), asUser("Alice"))
+		ts.record(request("POST", "/"+id+"/lock/0"), asUser("Alice"))
+		ts.record(request("POST", "/"+id+"/roll"), asUser("Alice"))
+
+		return ts.fromStore(id)
+	}
+
+	first := rollWithSeed("rollSeeded1ID", 1234)
+	second := rollWithSeed("rollSeeded2ID", 1234)
+	ts.Exactly(int64(1234), first.Seed)
+	ts.Exactly(9, first.RollCounter)
+	ts.Exactly(first.Dices, second.Dices)
+
+	// the roller continues from the recorded counter after loading
+	replayed := yahtzee.NewRoller(1234, 0)
+	for i := 0; i < 5; i++ {
+		replayed.Roll(6)
+	}
+	for _, d := range first.Dices[1:] {
+		ts.Exactly(replayed.Roll(6), d.Value)
+	}
+}
+
+func (ts *testSuite) TestRollSavedRolls() {
+	g := yahtzee.NewGame(yahtzee.SavedRolls)
+	g.Players = []*yahtzee.Player{
+		yahtzee.NewPlayer("Alice"),
+		yahtzee.NewPlayer("Bob"),
+	}
+	ts.Require().NoError(ts.store.Save("savedRollsID", *g))
+
+	// unused rolls are saved when scoring
+	rr := ts.record(request("POST", "/savedRollsID/roll"), asUser("Alice"))
+	ts.Require().Exactly(http.StatusOK, rr.Code)
+	rr = ts.record(request("POST", "/savedRollsID/score", "chance"), asUser("Alice"))
+	ts.Require().Exactly(http.StatusOK, rr.Code)
+
+	saved := ts.fromStore("savedRollsID")
+	ts.Exactly(2, saved.Players[0].SavedRolls)
+	ts.Exactly(0, saved.Players[1].SavedRolls)
+
+	// saved rolls can be used after the third roll
+	saved.CurrentPlayer = 0
+	saved.RollCount = 3
+	ts.Require().NoError(ts.store.Save("savedRollsID", *saved))
+
+	eChan := ts.receiveEvents("savedRollsID")
+	rr = ts.record(request("POST", "/savedRollsID/roll"), asUser("Alice"))
+	ts.Exactly(http.StatusOK, rr.Code)
+	if got := <-eChan; ts.NotNil(got) {
+		ts.Exactly(4, got.Data.(*handler.RollResponse).RollCount)
+		ts.Exactly(1, got.Data.(*handler.RollResponse).SavedRolls)
+	}
+	ts.Require().NoError(ts.event.Unsubscribe("savedRollsID", "savedRollsID"))
+
+	rr = ts.record(request("POST", "/savedRollsID/lock/0"), asUser("Alice"))
+	ts.Exactly(http.StatusOK, rr.Code)
+	rr = ts.record(request("POST", "/savedRollsID/roll"), asUser("Alice"))
+	ts.Exactly(http.StatusOK, rr.Code)
+
+	// out of saved rolls
+	rr = ts.record(request("POST", "/savedRollsID/roll"), asUser("Alice"))
+	ts.Exactly(http.StatusBadRequest, rr.Code)
+	rr = ts.record(request("POST", "/savedRollsID/lock/0"), asUser("Alice"))
+	ts.Exactly(http.StatusBadRequest, rr.Code)
+
+	// nothing is saved after using more rolls than a turn has
+	rr = ts.record(request("POST", "/savedRollsID/score", "ones"), asUser("Alice"))
+	ts.Exactly(http.StatusOK, rr.Code)
+	saved = ts.fromStore("savedRollsID")
+	ts.Exactly(0, saved.Players[0].SavedRolls)
+}
+
 func (ts *testSuite) TestLock() {
 	// missing user
 	rr := ts.record(request("POST", "/lockID/lock/2"))
@@ -1214,7 +1939,7 @@ func (ts *testSuite) TestScore() {
 	saved := ts.fromStore("scoreID")
 	if got := <-eChan; ts.NotNil(got) {
 		ts.Exactly(event.Score, got.Action)
-		ts.Exactly(saved, got.Data.(*yahtzee.Game))
+		ts.Exactly(saved, got.Data.(*handler.ScoreResponse).Game)
 	}
 
 	// scoring
@@ -1431,7 +2156,7 @@ func (ts *testSuite) TestScoreTheChance() {
 	saved := ts.fromStore("scoreID")
 	if got := <-eChan; ts.NotNil(got) {
 		ts.Exactly(event.Score, got.Action)
-		ts.Exactly(saved, got.Data.(*yahtzee.Game))
+		ts.Exactly(saved, got.Data.(*handler.ScoreResponse).Game)
 	}
 
 	saved.RollCount = 1
@@ -1481,15 +2206,68 @@ func (ts *testSuite) TestScoreTheChance() {
 		"Round": 13,
 		"CurrentPlayer": 0,
 		"RollCount": 0,
-		"Features": ["the-chance"]
+		"Features": ["the-chance"],
+		"Standings": [
+			{"User": "Alice", "Total": 500, "Place": 1},
+			{"User": "Bob", "Total": 6, "Place": 2}
+		]
 	}`, rr.Body.String())
 
 	saved = ts.fromStore("scoreID")
 	if got := <-eChan; ts.NotNil(got) {
 		ts.Exactly(event.Score, got.Action)
-		ts.Exactly(saved, got.Data.(*yahtzee.Game))
+		ts.Exactly(saved, got.Data.(*handler.ScoreResponse).Game)
+		ts.Exactly(saved.Standings(), got.Data.(*handler.ScoreResponse).Standings)
 	}
+}
+
+func (ts *testSuite) TestResults() {
+	// game not exists
+	rr := ts.record(request("GET", "/resultsID/results"))
+	ts.Exactly(http.StatusNotFound, rr.Code)
+
+	// game in progress
+	g := yahtzee.NewGame(yahtzee.YahtzeeBonus)
+	g.Players = []*yahtzee.Player{
+		yahtzee.NewPlayer("Alice"),
+		yahtzee.NewPlayer("Bob"),
+		yahtzee.NewPlayer("Carol"),
+	}
+	g.Players[0].ScoreSheet[yahtzee.Sixes] = 24
+	g.Players[0].ScoreSheet[yahtzee.Bonus] = 35
+	g.Players[1].ScoreSheet[yahtzee.Yahtzee] = 150
+	g.Players[2].ScoreSheet[yahtzee.Chance] = 20
+	g.Round = 5
+	ts.Require().NoError(ts.store.Save("resultsID", *g))
+
+	rr = ts.record(request("GET", "/resultsID/results"))
+	ts.Exactly(http.StatusOK, rr.Code)
+	ts.JSONEq(`{
+		"Finished": false,
+		"Standings": [
+			{"User": "Bob", "Total": 150, "Place": 1},
+			{"User": "Alice", "Total": 59, "Place": 2},
+			{"User": "Carol", "Total": 20, "Place": 3}
+		],
+		"Winners": []
+	}`, rr.Body.String())
+
+	// game over with a tie
+	g.Players[2].ScoreSheet[yahtzee.Chance] = 150
+	g.Round = 13
+	ts.Require().NoError(ts.store.Save("resultsID", *g))
 
+	rr = ts.record(request("GET", "/resultsID/results"))
+	ts.Exactly(http.StatusOK, rr.Code)
+	ts.JSONEq(`{
+		"Finished": true,
+		"Standings": [
+			{"User": "Bob", "Total": 150, "Place": 1},
+			{"User": "Carol", "Total": 150, "Place": 1},
+			{"User": "Alice", "Total": 59, "Place": 3}
+		],
+		"Winners": ["Bob", "Carol"]
+	}`, rr.Body.String())
 }
 
 func (ts *testSuite) TestScoreSixDice() {
@@ -1596,7 +2374,7 @@ func (ts *testSuite) TestScoreSixDice() {
 	saved := ts.fromStore("scoreID")
 	if got := <-eChan; ts.NotNil(got) {
 		ts.Exactly(event.Score, got.Action)
-		ts.Exactly(saved, got.Data.(*yahtzee.Game))
+		ts.Exactly(saved, got.Data.(*handler.ScoreResponse).Game)
 	}
 
 	// scoring
@@ -1811,7 +2589,7 @@ func (ts *testSuite) TestScoreYahtzeeBonus() {
 	saved := ts.fromStore("scoreID")
 	if got := <-eChan; ts.NotNil(got) {
 		ts.Exactly(event.Score, got.Action)
-		ts.Exactly(saved, got.Data.(*yahtzee.Game))
+		ts.Exactly(saved, got.Data.(*handler.ScoreResponse).Game)
 	}
 
 	// scoring
@@ -1972,6 +2750,28 @@ func (ts *testSuite) TestScoreYahtzeeBonus() {
 		{[]int{1, 1, 1, 1, 3}, []int{-1, 2, 3, 4, 15, 36}, yahtzee.Ones, true, true},
 	}
 
+	// the official rules override the yahtzee bonus
+	rr = ts.record(request("POST", "/", `["official","yahtzee-bonus"]`))
+	ts.Exactly(http.StatusCreated, rr.Code)
+
+	g = yahtzee.NewGame(yahtzee.Official, yahtzee.YahtzeeBonus)
+	g.Players = append(g.Players, yahtzee.NewPlayer("Alice"))
+	g.Players[0].ScoreSheet[yahtzee.Yahtzee] = 50
+	g.RollCount = 1
+	for _, d := range g.Dices {
+		d.Value = 3
+	}
+	ts.Require().NoError(ts.store.Save("score_overrideID", *g))
+
+	rr = ts.record(request("POST", "/score_overrideID/score", "full-house"), asUser("Alice"))
+	ts.Exactly(http.StatusBadRequest, rr.Code)
+	rr = ts.record(request("POST", "/score_overrideID/score", "threes"), asUser("Alice"))
+	ts.Exactly(http.StatusOK, rr.Code)
+
+	got := ts.fromStore("score_overrideID")
+	ts.Exactly(15, got.Players[0].ScoreSheet[yahtzee.Threes])
+	ts.Exactly(150, got.Players[0].ScoreSheet[yahtzee.Yahtzee])
+
 	for _, tc := range bonusCases {
 		g := yahtzee.NewGame(yahtzee.YahtzeeBonus)
 		g.Players = append(g.Players, yahtzee.NewPlayer("Alice"))
@@ -2115,7 +2915,7 @@ func (ts *testSuite) TestScoreOfficial() {
 	saved := ts.fromStore("scoreID")
 	if got := <-eChan; ts.NotNil(got) {
 		ts.Exactly(event.Score, got.Action)
-		ts.Exactly(saved, got.Data.(*yahtzee.Game))
+		ts.Exactly(saved, got.Data.(*handler.ScoreResponse).Game)
 	}
 
 	// scoring
@@ -2175,33 +2975,33 @@ func (ts *testSuite) TestScoreOfficial() {
 		{[]int{2, 4, 3, 6, 4}, true, 50, false, yahtzee.Twos, 2, yahtzee.ThreeOfAKind, 0, 50},
 		{[]int{3, 1, 3, 1, 3}, true, 50, false, yahtzee.Twos, 2, yahtzee.ThreeOfAKind, 11, 50},
 		{[]int{5, 2, 5, 5, 5}, true, 50, false, yahtzee.Twos, 2, yahtzee.ThreeOfAKind, 22, 50},
-		{[]int{5, 5, 5, 5, 5}, true, 50, false, yahtzee.Twos, 2, yahtzee.ThreeOfAKind, 25, 150},
+		{[]int{5, 5, 5, 5, 5}, true, 50, true, yahtzee.Fives, 25, yahtzee.ThreeOfAKind, 25, 150},
 		{[]int{2, 6, 3, 2, 2}, true, 50, false, yahtzee.Twos, 2, yahtzee.FourOfAKind, 0, 50},
 		{[]int{1, 6, 6, 6, 6}, true, 50, false, yahtzee.Twos, 2, yahtzee.FourOfAKind, 25, 50},
-		{[]int{4, 4, 4, 4, 4}, true, 50, false, yahtzee.Twos, 2, yahtzee.FourOfAKind, 20, 150},
+		{[]int{4, 4, 4, 4, 4}, true, 50, true, yahtzee.Fours, 20, yahtzee.FourOfAKind, 20, 150},
 		{[]int{5, 5, 2, 5, 5}, true, 50, false, yahtzee.Twos, 2, yahtzee.FullHouse, 0, 50},
 		{[]int{2, 5, 3, 6, 5}, true, 50, false, yahtzee.Twos, 2, yahtzee.FullHouse, 0, 50},
 		{[]int{5, 5, 2, 5, 2}, true, 50, false, yahtzee.Twos, 2, yahtzee.FullHouse, 25, 50},
 		{[]int{3, 1, 3, 1, 3}, true, 50, false, yahtzee.Twos, 2, yahtzee.FullHouse, 25, 50},
-		{[]int{3, 3, 3, 3, 3}, true, 50, false, yahtzee.Twos, 2, yahtzee.FullHouse, 0, 150},
+		{[]int{3, 3, 3, 3, 3}, true, 50, false, yahtzee.Twos, 2, yahtzee.FullHouse, 0, 50},
 		{[]int{3, 3, 3, 3, 3}, true, 50, true, yahtzee.Threes, 3, yahtzee.FullHouse, 25, 150},
 		{[]int{6, 2, 5, 1, 3}, true, 50, false, yahtzee.Twos, 2, yahtzee.SmallStraight, 0, 50},
 		{[]int{6, 2, 4, 1, 3}, true, 50, false, yahtzee.Twos, 2, yahtzee.SmallStraight, 30, 50},
 		{[]int{4, 2, 3, 5, 3}, true, 50, false, yahtzee.Twos, 2, yahtzee.SmallStraight, 30, 50},
 		{[]int{1, 6, 3, 5, 4}, true, 50, false, yahtzee.Twos, 2, yahtzee.SmallStraight, 30, 50},
-		{[]int{5, 5, 5, 5, 5}, true, 50, false, yahtzee.Twos, 2, yahtzee.SmallStraight, 0, 150},
+		{[]int{5, 5, 5, 5, 5}, true, 50, false, yahtzee.Twos, 2, yahtzee.SmallStraight, 0, 50},
 		{[]int{5, 5, 5, 5, 5}, true, 50, true, yahtzee.Fives, 15, yahtzee.SmallStraight, 30, 150},
 		{[]int{3, 5, 2, 3, 4}, true, 50, false, yahtzee.Twos, 2, yahtzee.LargeStraight, 0, 50},
 		{[]int{3, 5, 2, 1, 4}, true, 50, false, yahtzee.Twos, 2, yahtzee.LargeStraight, 40, 50},
 		{[]int{5, 2, 6, 3, 4}, true, 50, false, yahtzee.Twos, 2, yahtzee.LargeStraight, 40, 50},
-		{[]int{5, 5, 5, 5, 5}, true, 50, false, yahtzee.Twos, 2, yahtzee.LargeStraight, 0, 150},
+		{[]int{5, 5, 5, 5, 5}, true, 50, false, yahtzee.Twos, 2, yahtzee.LargeStraight, 0, 50},
 		{[]int{5, 5, 5, 5, 5}, true, 50, true, yahtzee.Fives, 10, yahtzee.LargeStraight, 40, 150},
 		{[]int{3, 3, 3, 3, 3}, true, 50, false, yahtzee.Twos, 2, yahtzee.Yahtzee, 50, 50},
 		{[]int{1, 1, 1, 1, 1}, true, 50, false, yahtzee.Twos, 2, yahtzee.Yahtzee, 50, 50},
 		{[]int{6, 2, 4, 1, 3}, true, 50, false, yahtzee.Twos, 2, yahtzee.Chance, 16, 50},
 		{[]int{1, 6, 3, 3, 5}, true, 50, false, yahtzee.Twos, 2, yahtzee.Chance, 18, 50},
 		{[]int{2, 3, 4, 2, 3}, true, 50, false, yahtzee.Twos, 2, yahtzee.Chance, 14, 50},
-		{[]int{2, 2, 2, 2, 2}, true, 50, false, yahtzee.Twos, 2, yahtzee.Chance, 10, 150},
+		{[]int{2, 2, 2, 2, 2}, true, 50, true, yahtzee.Twos, 10, yahtzee.Chance, 10, 150},
 		{[]int{1, 2, 3, 1, 1}, true, 0, false, yahtzee.Twos, 2, yahtzee.Ones, 3, 0},
 		{[]int{1, 1, 1, 1, 1}, true, 0, false, yahtzee.Twos, 2, yahtzee.Ones, 5, 0},
 		{[]int{2, 3, 4, 2, 3}, true, 0, false, yahtzee.Twos, 2, yahtzee.Twos, 4, 0},
@@ -2218,10 +3018,10 @@ func (ts *testSuite) TestScoreOfficial() {
 		{[]int{2, 4, 3, 6, 4}, true, 0, false, yahtzee.Twos, 2, yahtzee.ThreeOfAKind, 0, 0},
 		{[]int{3, 1, 3, 1, 3}, true, 0, false, yahtzee.Twos, 2, yahtzee.ThreeOfAKind, 11, 0},
 		{[]int{5, 2, 5, 5, 5}, true, 0, false, yahtzee.Twos, 2, yahtzee.ThreeOfAKind, 22, 0},
-		{[]int{5, 5, 5, 5, 5}, true, 0, false, yahtzee.Twos, 2, yahtzee.ThreeOfAKind, 25, 0},
+		{[]int{5, 5, 5, 5, 5}, true, 0, true, yahtzee.Fives, 25, yahtzee.ThreeOfAKind, 25, 0},
 		{[]int{2, 6, 3, 2, 2}, true, 0, false, yahtzee.Twos, 2, yahtzee.FourOfAKind, 0, 0},
 		{[]int{1, 6, 6, 6, 6}, true, 0, false, yahtzee.Twos, 2, yahtzee.FourOfAKind, 25, 0},
-		{[]int{4, 4, 4, 4, 4}, true, 0, false, yahtzee.Twos, 2, yahtzee.FourOfAKind, 20, 0},
+		{[]int{4, 4, 4, 4, 4}, true, 0, true, yahtzee.Fours, 20, yahtzee.FourOfAKind, 20, 0},
 		{[]int{5, 5, 2, 5, 5}, true, 0, false, yahtzee.Twos, 2, yahtzee.FullHouse, 0, 0},
 		{[]int{2, 5, 3, 6, 5}, true, 0, false, yahtzee.Twos, 2, yahtzee.FullHouse, 0, 0},
 		{[]int{5, 5, 2, 5, 2}, true, 0, false, yahtzee.Twos, 2, yahtzee.FullHouse, 25, 0},
@@ -2244,7 +3044,7 @@ func (ts *testSuite) TestScoreOfficial() {
 		{[]int{6, 2, 4, 1, 3}, true, 0, false, yahtzee.Twos, 2, yahtzee.Chance, 16, 0},
 		{[]int{1, 6, 3, 3, 5}, true, 0, false, yahtzee.Twos, 2, yahtzee.Chance, 18, 0},
 		{[]int{2, 3, 4, 2, 3}, true, 0, false, yahtzee.Twos, 2, yahtzee.Chance, 14, 0},
-		{[]int{2, 2, 2, 2, 2}, true, 0, false, yahtzee.Twos, 2, yahtzee.Chance, 10, 0},
+		{[]int{2, 2, 2, 2, 2}, true, 0, true, yahtzee.Twos, 10, yahtzee.Chance, 10, 0},
 	}
 
 	for _, tc := range scoringCases {
@@ -2272,6 +3072,67 @@ func (ts *testSuite) TestScoreOfficial() {
 			"should return %d for yahtzee for %q on %v", tc.yahtzee, tc.category, tc.dices)
 	}
 
+	// joker placement
+	jokerCases := []struct {
+		sheet    map[yahtzee.Category]int
+		category yahtzee.Category
+		allowed  bool
+	}{
+		{map[yahtzee.Category]int{yahtzee.Yahtzee: 50}, yahtzee.Sixes, true},
+		{map[yahtzee.Category]int{yahtzee.Yahtzee: 50}, yahtzee.Chance, false},
+		{map[yahtzee.Category]int{yahtzee.Yahtzee: 50}, yahtzee.Ones, false},
+		{map[yahtzee.Category]int{yahtzee.Yahtzee: 0}, yahtzee.FullHouse, false},
+		{map[yahtzee.Category]int{yahtzee.Yahtzee: 50, yahtzee.Sixes: 24}, yahtzee.FullHouse, true},
+		{map[yahtzee.Category]int{yahtzee.Yahtzee: 50, yahtzee.Sixes: 24}, yahtzee.Ones, false},
+		{map[yahtzee.Category]int{
+			yahtzee.Yahtzee:       50,
+			yahtzee.Sixes:         24,
+			yahtzee.ThreeOfAKind:  20,
+			yahtzee.FourOfAKind:   20,
+			yahtzee.FullHouse:     25,
+			yahtzee.SmallStraight: 30,
+			yahtzee.LargeStraight: 40,
+			yahtzee.Chance:        20,
+		}, yahtzee.Ones, true},
+		{map[yahtzee.Category]int{}, yahtzee.Chance, true},
+	}
+
+	for _, tc := range jokerCases {
+		g := yahtzee.NewGame(yahtzee.Official)
+		g.Players = append(g.Players, yahtzee.NewPlayer("Alice"))
+		g.RollCount = 1
+		for c, v := range tc.sheet {
+			g.Players[0].ScoreSheet[c] = v
+		}
+		for _, d := range g.Dices {
+			d.Value = 6
+		}
+		ts.Require().NoError(ts.store.Save("score_jokerID", *g))
+
+		rr := ts.record(request("GET", "/score_jokerID/hints"))
+		ts.Exactly(http.StatusOK, rr.Code)
+		var points map[yahtzee.Category]int
+		ts.Require().NoError(json.Unmarshal(rr.Body.Bytes(), &points))
+		ts.Contains(points, tc.category)
+		if !tc.allowed {
+			ts.Exactly(0, points[tc.category], "hinting %q with %v", tc.category, tc.sheet)
+		}
+
+		rr = ts.record(request("GET", "/score_jokerID/hints"), withQuery("allowed", "true"))
+		ts.Exactly(http.StatusOK, rr.Code)
+		var hints handler.HintsResponse
+		ts.Require().NoError(json.Unmarshal(rr.Body.Bytes(), &hints))
+		ts.Exactly(tc.allowed, hints.Allowed[tc.category], "hinting %q with %v", tc.category, tc.sheet)
+		ts.Len(hints.Allowed, len(points))
+
+		rr = ts.record(request("POST", "/score_jokerID/score", string(tc.category)), asUser("Alice"))
+		if tc.allowed {
+			ts.Exactly(http.StatusOK, rr.Code, "scoring %q with %v", tc.category, tc.sheet)
+		} else {
+			ts.Exactly(http.StatusBadRequest, rr.Code, "scoring %q with %v", tc.category, tc.sheet)
+		}
+	}
+
 	// bonus
 	bonusCases := []struct {
 		dices         []int
@@ -2452,23 +3313,1230 @@ func (ts *testSuite) TestScoreOrdered() {
 	}
 }
 
-func (ts *testSuite) TestWS() {
-	server := httptest.NewServer(ts.handler)
-	defer server.Close()
-	baseUrl := "ws" + strings.TrimPrefix(server.URL, "http")
+func (ts *testSuite) TestScoreYacht() {
+	// categories of the yahtzee rules
+	g := yahtzee.NewGame(yahtzee.YachtRules)
+	g.Players = append(g.Players, yahtzee.NewPlayer("Alice"))
+	g.RollCount = 1
+	ts.Require().NoError(ts.store.Save("scoreYachtID", *g))
 
-	ts.Require().NoError(ts.store.Save("wsID", *yahtzee.NewGame()))
+	rr := ts.record(request("POST", "/scoreYachtID/score", "yahtzee"), asUser("Alice"))
+	ts.Exactly(http.StatusBadRequest, rr.Code)
+	rr = ts.record(request("POST", "/scoreYachtID/score", "small-straight"), asUser("Alice"))
+	ts.Exactly(http.StatusBadRequest, rr.Code)
 
-	ws, _, err := websocket.DefaultDialer.Dial(baseUrl+"/wsID/ws", nil)
-	if !ts.NoError(err) {
-		return
+	// scoring
+	scoringCases := []struct {
+		dices    []int
+		category yahtzee.Category
+		value    int
+	}{
+		{[]int{1, 2, 3, 1, 1}, yahtzee.Ones, 3},
+		{[]int{5, 3, 6, 6, 6}, yahtzee.Sixes, 18},
+		{[]int{5, 5, 2, 5, 5}, yahtzee.FullHouse, 0},
+		{[]int{5, 5, 5, 5, 5}, yahtzee.FullHouse, 0},
+		{[]int{5, 5, 2, 5, 2}, yahtzee.FullHouse, 19},
+		{[]int{3, 1, 3, 1, 3}, yahtzee.FullHouse, 11},
+		{[]int{2, 6, 3, 2, 2}, yahtzee.FourOfAKind, 0},
+		{[]int{1, 6, 6, 6, 6}, yahtzee.FourOfAKind, 24},
+		{[]int{4, 4, 4, 4, 4}, yahtzee.FourOfAKind, 16},
+		{[]int{6, 2, 4, 1, 3}, yahtzee.LittleStraight, 0},
+		{[]int{3, 5, 2, 1, 4}, yahtzee.LittleStraight, 30},
+		{[]int{5, 2, 6, 3, 4}, yahtzee.LittleStraight, 0},
+		{[]int{3, 5, 2, 1, 4}, yahtzee.BigStraight, 0},
+		{[]int{5, 2, 6, 3, 4}, yahtzee.BigStraight, 30},
+		{[]int{6, 2, 4, 1, 3}, yahtzee.Choice, 16},
+		{[]int{3, 3, 3, 3, 3}, yahtzee.Yacht, 50},
+		{[]int{3, 3, 3, 3, 1}, yahtzee.Yacht, 0},
 	}
-	defer ws.Close()
 
-	ts.event.Emit("wsID", yahtzee.NewUser("Alice"), event.AddPlayer, nil)
+	for _, tc := range scoringCases {
+		g := yahtzee.NewGame(yahtzee.YachtRules)
+		g.Players = append(g.Players, yahtzee.NewPlayer("Alice"))
+		g.RollCount = 1
+		for d := 0; d < 5; d++ {
+			g.Dices[d].Value = tc.dices[d]
+		}
+		ts.Require().NoError(ts.store.Save("scoreYachtID", *g))
 
-	_, p, err := ws.ReadMessage()
-	if ts.NoError(err) {
+		rr := ts.record(request("POST", "/scoreYachtID/score", string(tc.category)), asUser("Alice"))
+		ts.Require().Exactly(http.StatusOK, rr.Code)
+
+		got := ts.fromStore("scoreYachtID")
+		ts.Exactly(tc.value, got.Players[0].ScoreSheet[tc.category],
+			"should return %d for %q on %v", tc.value, tc.category, tc.dices)
+	}
+
+	// no upper section bonus, the game lasts 12 rounds
+	g = yahtzee.NewGame(yahtzee.YachtRules)
+	g.Players = append(g.Players, yahtzee.NewPlayer("Alice"))
+	g.Players[0].ScoreSheet = map[yahtzee.Category]int{
+		yahtzee.Ones:           5,
+		yahtzee.Twos:           10,
+		yahtzee.Threes:         15,
+		yahtzee.Fours:          20,
+		yahtzee.Fives:          25,
+		yahtzee.FullHouse:      28,
+		yahtzee.FourOfAKind:    24,
+		yahtzee.LittleStraight: 30,
+		yahtzee.BigStraight:    30,
+		yahtzee.Choice:         20,
+		yahtzee.Yacht:          50,
+	}
+	g.Round = 11
+	g.RollCount = 1
+	for _, d := range g.Dices {
+		d.Value = 6
+	}
+	ts.Require().NoError(ts.store.Save("scoreYachtID", *g))
+
+	rr = ts.record(request("POST", "/scoreYachtID/score", "sixes"), asUser("Alice"))
+	ts.Exactly(http.StatusOK, rr.Code)
+
+	got := ts.fromStore("scoreYachtID")
+	ts.NotContains(got.Players[0].ScoreSheet, yahtzee.Bonus)
+	ts.True(got.IsOver())
+	ts.Exactly(287, got.Players[0].Total())
+}
+
+func (ts *testSuite) TestScoreGenerala() {
+	// scoring
+	scoringCases := []struct {
+		dices     []int
+		rollCount int
+		category  yahtzee.Category
+		value     int
+	}{
+		{[]int{1, 2, 3, 1, 1}, 2, yahtzee.Ones, 3},
+		{[]int{5, 3, 6, 6, 6}, 1, yahtzee.Sixes, 18},
+		{[]int{3, 5, 2, 1, 4}, 2, yahtzee.Escalera, 20},
+		{[]int{3, 5, 2, 1, 4}, 1, yahtzee.Escalera, 25},
+		{[]int{5, 2, 6, 3, 4}, 3, yahtzee.Escalera, 20},
+		{[]int{5, 1, 6, 3, 4}, 3, yahtzee.Escalera, 20},
+		{[]int{5, 1, 6, 3, 3}, 1, yahtzee.Escalera, 0},
+		{[]int{5, 5, 2, 5, 2}, 3, yahtzee.Full, 30},
+		{[]int{5, 5, 2, 5, 2}, 1, yahtzee.Full, 35},
+		{[]int{5, 5, 3, 5, 2}, 1, yahtzee.Full, 0},
+		{[]int{1, 6, 6, 6, 6}, 2, yahtzee.Poker, 40},
+		{[]int{1, 6, 6, 6, 6}, 1, yahtzee.Poker, 45},
+		{[]int{1, 1, 6, 6, 6}, 1, yahtzee.Poker, 0},
+		{[]int{4, 4, 4, 4, 4}, 3, yahtzee.Generala, 50},
+		{[]int{4, 4, 4, 4, 3}, 3, yahtzee.Generala, 0},
+	}
+
+	for _, tc := range scoringCases {
+		g := yahtzee.NewGame(yahtzee.GeneralaRules)
+		g.Players = append(g.Players, yahtzee.NewPlayer("Alice"))
+		g.RollCount = tc.rollCount
+		for d := 0; d < 5; d++ {
+			g.Dices[d].Value = tc.dices[d]
+		}
+		ts.Require().NoError(ts.store.Save("scoreGeneralaID", *g))
+
+		rr := ts.record(request("POST", "/scoreGeneralaID/score", string(tc.category)), asUser("Alice"))
+		ts.Require().Exactly(http.StatusOK, rr.Code)
+
+		got := ts.fromStore("scoreGeneralaID")
+		ts.Exactly(tc.value, got.Players[0].ScoreSheet[tc.category],
+			"should return %d for %q on %v after %d rolls", tc.value, tc.category, tc.dices, tc.rollCount)
+		ts.NotContains(got.Players[0].ScoreSheet, yahtzee.Bonus)
+	}
+
+	// served generala wins the game
+	g := yahtzee.NewGame(yahtzee.GeneralaRules)
+	g.Players = []*yahtzee.Player{
+		yahtzee.NewPlayer("Alice"),
+		yahtzee.NewPlayer("Bob"),
+	}
+	g.Players[0].ScoreSheet[yahtzee.Poker] = 40
+	g.Players[0].ScoreSheet[yahtzee.Full] = 30
+	g.CurrentPlayer = 1
+	g.Round = 3
+	g.RollCount = 1
+	for _, d := range g.Dices {
+		d.Value = 2
+	}
+	ts.Require().NoError(ts.store.Save("scoreGeneralaID", *g))
+	eChan := ts.receiveEvents("scoreGeneralaID")
+
+	rr := ts.record(request("POST", "/scoreGeneralaID/score", "twos"), asUser("Bob"))
+	ts.Exactly(http.StatusOK, rr.Code)
+
+	saved := ts.fromStore("scoreGeneralaID")
+	ts.True(saved.IsOver())
+	ts.Exactly(yahtzee.User("Bob"), saved.Winner)
+	ts.Exactly([]yahtzee.User{"Bob"}, saved.Winners())
+	if got := <-eChan; ts.NotNil(got) {
+		ts.Exactly([]yahtzee.Standing{
+			{User: "Bob", Total: 10, Place: 1},
+			{User: "Alice", Total: 70, Place: 2},
+		}, got.Data.(*handler.ScoreResponse).Standings)
+	}
+
+	rr = ts.record(request("POST", "/scoreGeneralaID/roll"), asUser("Alice"))
+	ts.Exactly(http.StatusBadRequest, rr.Code)
+}
+
+func (ts *testSuite) TestScoreMaxi() {
+	// scoring
+	scoringCases := []struct {
+		dices    []int
+		category yahtzee.Category
+		value    int
+	}{
+		{[]int{1, 1, 1, 1, 1, 1}, yahtzee.Ones, 6},
+		{[]int{5, 3, 6, 6, 6, 6}, yahtzee.Sixes, 24},
+		{[]int{1, 2, 3, 4, 5, 6}, yahtzee.OnePair, 0},
+		{[]int{1, 2, 2, 4, 4, 6}, yahtzee.OnePair, 8},
+		{[]int{1, 2, 2, 4, 4, 6}, yahtzee.TwoPairs, 12},
+		{[]int{1, 2, 2, 2, 2, 6}, yahtzee.TwoPairs, 0},
+		{[]int{1, 1, 2, 2, 6, 6}, yahtzee.ThreePairs, 18},
+		{[]int{1, 1, 2, 2, 6, 5}, yahtzee.ThreePairs, 0},
+		{[]int{3, 3, 3, 5, 5, 5}, yahtzee.ThreeOfAKind, 15},
+		{[]int{3, 3, 3, 3, 5, 5}, yahtzee.FourOfAKind, 12},
+		{[]int{3, 3, 3, 3, 3, 5}, yahtzee.FiveOfAKind, 15},
+		{[]int{3, 3, 3, 3, 5, 5}, yahtzee.FiveOfAKind, 0},
+		{[]int{1, 2, 3, 4, 5, 5}, yahtzee.SmallStraight, 15},
+		{[]int{2, 3, 4, 5, 6, 6}, yahtzee.SmallStraight, 0},
+		{[]int{2, 3, 4, 5, 6, 6}, yahtzee.LargeStraight, 20},
+		{[]int{6, 5, 4, 3, 2, 1}, yahtzee.FullStraight, 21},
+		{[]int{6, 5, 4, 3, 2, 2}, yahtzee.FullStraight, 0},
+		{[]int{2, 2, 2, 5, 5, 1}, yahtzee.FullHouse, 16},
+		{[]int{2, 2, 2, 5, 5, 5}, yahtzee.FullHouse, 19},
+		{[]int{2, 2, 2, 5, 5, 5}, yahtzee.Castle, 21},
+		{[]int{2, 2, 2, 5, 5, 1}, yahtzee.Castle, 0},
+		{[]int{4, 4, 4, 4, 1, 1}, yahtzee.Tower, 18},
+		{[]int{4, 4, 4, 4, 1, 2}, yahtzee.Tower, 0},
+		{[]int{4, 4, 4, 4, 1, 2}, yahtzee.Chance, 19},
+		{[]int{4, 4, 4, 4, 4, 4}, yahtzee.MaxiYahtzee, 100},
+		{[]int{4, 4, 4, 4, 4, 1}, yahtzee.MaxiYahtzee, 0},
+	}
+
+	for _, tc := range scoringCases {
+		g := yahtzee.NewGame(yahtzee.MaxiRules)
+		g.Players = append(g.Players, yahtzee.NewPlayer("Alice"))
+		g.RollCount = 1
+		for d := 0; d < 6; d++ {
+			g.Dices[d].Value = tc.dices[d]
+		}
+		ts.Require().NoError(ts.store.Save("scoreMaxiID", *g))
+
+		rr := ts.record(request("POST", "/scoreMaxiID/score", string(tc.category)), asUser("Alice"))
+		ts.Require().Exactly(http.StatusOK, rr.Code)
+
+		got := ts.fromStore("scoreMaxiID")
+		ts.Exactly(tc.value, got.Players[0].ScoreSheet[tc.category],
+			"should return %d for %q on %v", tc.value, tc.category, tc.dices)
+	}
+
+	// bonus over 84 points in the upper section
+	bonusCases := []struct {
+		dices        []int
+		upperSection map[yahtzee.Category]int
+		bonus        int
+	}{
+		{[]int{6, 6, 6, 6, 1, 1}, map[yahtzee.Category]int{yahtzee.Ones: 4, yahtzee.Twos: 8, yahtzee.Threes: 12, yahtzee.Fours: 16, yahtzee.Fives: 20}, 50},
+		{[]int{6, 6, 6, 1, 1, 1}, map[yahtzee.Category]int{yahtzee.Ones: 4, yahtzee.Twos: 8, yahtzee.Threes: 12, yahtzee.Fours: 16, yahtzee.Fives: 20}, 0},
+	}
+
+	for _, tc := range bonusCases {
+		g := yahtzee.NewGame(yahtzee.MaxiRules)
+		g.Players = append(g.Players, yahtzee.NewPlayer("Alice"))
+		g.Players[0].ScoreSheet = tc.upperSection
+		g.RollCount = 1
+		for d := 0; d < 6; d++ {
+			g.Dices[d].Value = tc.dices[d]
+		}
+		ts.Require().NoError(ts.store.Save("scoreMaxiID", *g))
+
+		rr := ts.record(request("POST", "/scoreMaxiID/score", "sixes"), asUser("Alice"))
+		ts.Require().Exactly(http.StatusOK, rr.Code)
+
+		got := ts.fromStore("scoreMaxiID")
+		if ts.Contains(got.Players[0].ScoreSheet, yahtzee.Bonus) {
+			ts.Exactly(tc.bonus, got.Players[0].ScoreSheet[yahtzee.Bonus])
+		}
+	}
+
+	// the game lasts 20 rounds
+	g := yahtzee.NewGame(yahtzee.MaxiRules)
+	g.Players = append(g.Players, yahtzee.NewPlayer("Alice"))
+	g.Round = 13
+	ts.Require().NoError(ts.store.Save("scoreMaxiID", *g))
+
+	rr := ts.record(request("POST", "/scoreMaxiID/roll"), asUser("Alice"))
+	ts.Exactly(http.StatusOK, rr.Code)
+
+	g.Round = 20
+	g.RollCount = 0
+	ts.Require().NoError(ts.store.Save("scoreMaxiID", *g))
+
+	rr = ts.record(request("POST", "/scoreMaxiID/roll"), asUser("Alice"))
+	ts.Exactly(http.StatusBadRequest, rr.Code)
+}
+
+func (ts *testSuite) TestPlayPokerDice() {
+	// hand ranks
+	handCases := []struct {
+		dices string
+		rank  yahtzee.HandRank
+	}{
+		{"[1,3,5,6,2]", yahtzee.Bust},
+		{"[1,3,5,6,3]", yahtzee.PairHand},
+		{"[1,3,1,6,3]", yahtzee.TwoPairHand},
+		{"[4,3,4,6,4]", yahtzee.ThreeOfAKindHand},
+		{"[4,3,5,2,1]", yahtzee.StraightHand},
+		{"[4,3,5,2,6]", yahtzee.StraightHand},
+		{"[4,6,4,6,4]", yahtzee.FullHouseHand},
+		{"[4,4,4,6,4]", yahtzee.FourOfAKindHand},
+		{"[2,2,2,2,2]", yahtzee.FiveOfAKindHand},
+	}
+	for _, tc := range handCases {
+		rr := ts.record(request("POST", "/score", `{"Dices":`+tc.dices+`,"Features":["poker-dice"]}`))
+		ts.Require().Exactly(http.StatusOK, rr.Code)
+		var got map[yahtzee.Category]int
+		ts.Require().NoError(json.Unmarshal(rr.Body.Bytes(), &got))
+		ts.Exactly(map[yahtzee.Category]int{yahtzee.Hand: tc.rank.Value()}, got, "on %s", tc.dices)
+	}
+
+	// rounds decided by comparing hands
+	g := yahtzee.NewGameWithOptions(yahtzee.GameOptions{Rounds: 2}, yahtzee.PokerDice)
+	g.Players = []*yahtzee.Player{
+		yahtzee.NewPlayer("Alice"),
+		yahtzee.NewPlayer("Bob"),
+	}
+	g.Roller = &scriptedRoller{values: []int{
+		6, 6, 6, 2, 2,
+		1, 2, 3, 4, 5,
+		3, 3, 1, 2, 4,
+		3, 3, 1, 2, 4,
+	}}
+	ts.Require().NoError(ts.store.Save("pokerDiceID", *g))
+
+	rr := ts.record(request("POST", "/pokerDiceID/roll"), asUser("Alice"))
+	ts.Exactly(http.StatusOK, rr.Code)
+	ts.JSONEq(`{
+		"Dices": [
+			{"Value": 6, "Locked": false, "Face": "A"},
+			{"Value": 6, "Locked": false, "Face": "A"},
+			{"Value": 6, "Locked": false, "Face": "A"},
+			{"Value": 2, "Locked": false, "Face": "10"},
+			{"Value": 2, "Locked": false, "Face": "10"}
+		],
+		"RollCount": 1
+	}`, rr.Body.String())
+
+	rr = ts.record(request("POST", "/pokerDiceID/score", "full-house"), asUser("Alice"))
+	ts.Exactly(http.StatusBadRequest, rr.Code)
+
+	rr = ts.record(request("POST", "/pokerDiceID/score", "hand"), asUser("Alice"))
+	ts.Exactly(http.StatusOK, rr.Code)
+
+	ts.record(request("POST", "/pokerDiceID/roll"), asUser("Bob"))
+	rr = ts.record(request("POST", "/pokerDiceID/score", "hand"), asUser("Bob"))
+	ts.Exactly(http.StatusOK, rr.Code)
+
+	got := ts.fromStore("pokerDiceID")
+	ts.Exactly([]yahtzee.User{"Alice"}, got.RoundWinners(0))
+	ts.Exactly(yahtzee.FullHouseHand, got.Players[0].Hands[0].Rank)
+	ts.Exactly(yahtzee.StraightHand, got.Players[1].Hands[0].Rank)
+	ts.Exactly(map[yahtzee.Category]int{yahtzee.RoundsWon: 1}, got.Players[0].ScoreSheet)
+	ts.Exactly(map[yahtzee.Category]int{}, got.Players[1].ScoreSheet)
+
+	// tied hands win the round for both players
+	for _, u := range []string{"Alice", "Bob"} {
+		ts.record(request("POST", "/pokerDiceID/roll"), asUser(u))
+		rr = ts.record(request("POST", "/pokerDiceID/score", "hand"), asUser(u))
+		ts.Exactly(http.StatusOK, rr.Code)
+	}
+
+	rr = ts.record(request("GET", "/pokerDiceID/results"))
+	ts.Exactly(http.StatusOK, rr.Code)
+	ts.JSONEq(`{
+		"Finished": true,
+		"Standings": [
+			{"User": "Alice", "Total": 2, "Place": 1},
+			{"User": "Bob", "Total": 1, "Place": 2}
+		],
+		"Winners": ["Alice"]
+	}`, rr.Body.String())
+}
+
+func (ts *testSuite) TestScoreTriple() {
+	g := yahtzee.NewGame(yahtzee.Triple)
+	ts.Require().NoError(g.AddPlayer("Alice"))
+	ts.Require().NoError(g.AddPlayer("Bob"))
+	g.RollCount = 1
+	ts.Require().NoError(ts.store.Save("scoreTripleID", *g))
+
+	// invalid column
+	rr := ts.record(request("POST", "/scoreTripleID/score", "chance"), withQuery("column", "3"), asUser("Alice"))
+	ts.Exactly(http.StatusBadRequest, rr.Code)
+	rr = ts.record(request("POST", "/scoreTripleID/score", "chance"), withQuery("column", "x"), asUser("Alice"))
+	ts.Exactly(http.StatusBadRequest, rr.Code)
+
+	// successful request
+	rr = ts.record(request("POST", "/scoreTripleID/score", "chance"), withQuery("column", "2"), asUser("Alice"))
+	ts.Exactly(http.StatusOK, rr.Code)
+
+	saved := ts.fromStore("scoreTripleID")
+	ts.Exactly([]map[yahtzee.Category]int{{}, {}, {yahtzee.Chance: 5}}, saved.Players[0].Columns)
+	ts.Empty(saved.Players[0].ScoreSheet)
+	ts.Exactly(1, saved.CurrentPlayer)
+
+	// category is already scored in the column only
+	saved.CurrentPlayer = 0
+	saved.RollCount = 1
+	ts.Require().NoError(ts.store.Save("scoreTripleID", *saved))
+
+	rr = ts.record(request("POST", "/scoreTripleID/score", "chance"), withQuery("column", "2"), asUser("Alice"))
+	ts.Exactly(http.StatusBadRequest, rr.Code)
+	rr = ts.record(request("POST", "/scoreTripleID/score", "chance"), asUser("Alice"))
+	ts.Exactly(http.StatusOK, rr.Code)
+
+	// game lasts for 39 rounds
+	g = yahtzee.NewGame(yahtzee.Triple)
+	ts.Require().NoError(g.AddPlayer("Alice"))
+	ts.Require().NoError(g.AddPlayer("Bob"))
+	g.Players[0].Columns[0][yahtzee.Chance] = 10
+	g.Players[0].Columns[1][yahtzee.Chance] = 10
+	g.Players[0].Columns[2][yahtzee.Chance] = 10
+	g.Players[1].Columns[0][yahtzee.Chance] = 25
+	g.Players[1].Columns[1][yahtzee.Chance] = 25
+	g.Round = 38
+	g.CurrentPlayer = 1
+	ts.Require().NoError(ts.store.Save("scoreTripleID", *g))
+
+	rr = ts.record(request("POST", "/scoreTripleID/roll"), asUser("Bob"))
+	ts.Exactly(http.StatusOK, rr.Code)
+
+	saved = ts.fromStore("scoreTripleID")
+	for _, d := range saved.Dices {
+		d.Value = 2
+	}
+	ts.Require().NoError(ts.store.Save("scoreTripleID", *saved))
+
+	rr = ts.record(request("POST", "/scoreTripleID/score", "chance"), withQuery("column", "2"), asUser("Bob"))
+	ts.Exactly(http.StatusOK, rr.Code)
+
+	saved = ts.fromStore("scoreTripleID")
+	ts.True(saved.IsOver())
+	ts.Exactly([]yahtzee.Standing{
+		{User: "Bob", Total: 25 + 2*25 + 3*10, Place: 1},
+		{User: "Alice", Total: 10 + 2*10 + 3*10, Place: 2},
+	}, saved.Standings())
+}
+
+func (ts *testSuite) TestHintsForGameTriple() {
+	g := yahtzee.NewGame(yahtzee.Triple, yahtzee.YahtzeeBonus)
+	ts.Require().NoError(g.AddPlayer("Alice"))
+	g.Players[0].Columns[1][yahtzee.Yahtzee] = 50
+	for _, d := range g.Dices {
+		d.Value = 4
+	}
+	ts.Require().NoError(ts.store.Save("hintsTripleID", *g))
+
+	rr := ts.record(request("GET", "/hintsTripleID/hints"), withQuery("column", "3"))
+	ts.Exactly(http.StatusBadRequest, rr.Code)
+
+	hints := func(column string) map[yahtzee.Category]int {
+		rr := ts.record(request("GET", "/hintsTripleID/hints"), withQuery("column", column))
+		ts.Require().Exactly(http.StatusOK, rr.Code)
+		var res map[yahtzee.Category]int
+		ts.Require().NoError(json.Unmarshal(rr.Body.Bytes(), &res))
+		return res
+	}
+
+	ts.Exactly(0, hints("0")[yahtzee.FullHouse])
+	ts.Exactly(25, hints("1")[yahtzee.FullHouse])
+	ts.Exactly(0, hints("2")[yahtzee.FullHouse])
+}
+
+func (ts *testSuite) TestPlayTeams() {
+	g := yahtzee.NewGameWithOptions(yahtzee.GameOptions{Rounds: 2}, yahtzee.Teams)
+	g.Roller = &scriptedRoller{values: []int{
+		1, 2, 3, 4, 5,
+		6, 6, 6, 6, 6,
+		6, 6, 6, 6, 6,
+		1, 1, 1, 1, 1,
+	}}
+	ts.Require().NoError(ts.store.Save("teamsID", *g))
+
+	// team required
+	rr := ts.record(request("POST", "/teamsID/join"), asUser("Alice"))
+	ts.Exactly(http.StatusBadRequest, rr.Code)
+
+	rr = ts.record(request("POST", "/teamsID/join"), asUser("Alice"), withQuery("team", "red"))
+	ts.Exactly(http.StatusCreated, rr.Code)
+	rr = ts.record(request("POST", "/teamsID/join"), asUser("Bob"), withQuery("team", "blue"))
+	ts.Exactly(http.StatusCreated, rr.Code)
+	rr = ts.record(request("POST", "/teamsID/join"), asUser("Carol"), withQuery("team", "red"))
+	ts.Exactly(http.StatusCreated, rr.Code)
+	rr = ts.record(request("POST", "/teamsID/join"), asUser("Dave"), withQuery("team", "blue"))
+	ts.Exactly(http.StatusCreated, rr.Code)
+	ts.JSONEq(`{
+		"Players": [
+			{"User": "Alice", "ScoreSheet": {}, "Team": "red"},
+			{"User": "Bob", "ScoreSheet": {}, "Team": "blue"},
+			{"User": "Carol", "ScoreSheet": {}, "Team": "red"},
+			{"User": "Dave", "ScoreSheet": {}, "Team": "blue"}
+		],
+		"Teams": [
+			{"Name": "red", "Members": ["Alice", "Carol"], "ScoreSheet": {}},
+			{"Name": "blue", "Members": ["Bob", "Dave"], "ScoreSheet": {}}
+		]
+	}`, rr.Body.String())
+
+	// first round
+	rr = ts.record(request("POST", "/teamsID/roll"), asUser("Alice"))
+	ts.Exactly(http.StatusOK, rr.Code)
+	rr = ts.record(request("POST", "/teamsID/score", "chance"), asUser("Alice"))
+	ts.Exactly(http.StatusOK, rr.Code)
+
+	rr = ts.record(request("POST", "/teamsID/roll"), asUser("Carol"))
+	ts.Exactly(http.StatusBadRequest, rr.Code)
+	rr = ts.record(request("POST", "/teamsID/roll"), asUser("Bob"))
+	ts.Exactly(http.StatusOK, rr.Code)
+	rr = ts.record(request("POST", "/teamsID/score", "yahtzee"), asUser("Bob"))
+	ts.Exactly(http.StatusOK, rr.Code)
+
+	// second round with the other members
+	rr = ts.record(request("POST", "/teamsID/roll"), asUser("Alice"))
+	ts.Exactly(http.StatusBadRequest, rr.Code)
+	rr = ts.record(request("POST", "/teamsID/roll"), asUser("Carol"))
+	ts.Exactly(http.StatusOK, rr.Code)
+	rr = ts.record(request("POST", "/teamsID/score", "chance"), asUser("Carol"))
+	ts.Exactly(http.StatusBadRequest, rr.Code)
+	rr = ts.record(request("POST", "/teamsID/score", "yahtzee"), asUser("Carol"))
+	ts.Exactly(http.StatusOK, rr.Code)
+
+	rr = ts.record(request("POST", "/teamsID/roll"), asUser("Dave"))
+	ts.Exactly(http.StatusOK, rr.Code)
+	rr = ts.record(request("POST", "/teamsID/score", "chance"), asUser("Dave"))
+	ts.Exactly(http.StatusOK, rr.Code)
+
+	saved := ts.fromStore("teamsID")
+	ts.True(saved.IsOver())
+	ts.Exactly(map[yahtzee.Category]int{
+		yahtzee.Chance:  15,
+		yahtzee.Yahtzee: 50,
+	}, saved.Team("red").ScoreSheet)
+	ts.Exactly(map[yahtzee.Category]int{
+		yahtzee.Yahtzee: 50,
+		yahtzee.Chance:  5,
+	}, saved.Team("blue").ScoreSheet)
+	ts.Empty(saved.Players[0].ScoreSheet)
+
+	rr = ts.record(request("GET", "/teamsID/results"))
+	ts.Exactly(http.StatusOK, rr.Code)
+	ts.JSONEq(`{
+		"Finished": true,
+		"Standings": [
+			{"User": "Alice", "Total": 65, "Team": "red", "Place": 1},
+			{"User": "Carol", "Total": 65, "Team": "red", "Place": 1},
+			{"User": "Bob", "Total": 55, "Team": "blue", "Place": 3},
+			{"User": "Dave", "Total": 55, "Team": "blue", "Place": 3}
+		],
+		"Winners": ["Alice", "Carol"]
+	}`, rr.Body.String())
+
+	// no teams in the game
+	g = yahtzee.NewGame()
+	ts.Require().NoError(ts.store.Save("teamsID", *g))
+
+	rr = ts.record(request("POST", "/teamsID/join"), asUser("Alice"), withQuery("team", "red"))
+	ts.Exactly(http.StatusBadRequest, rr.Code)
+}
+
+func (ts *testSuite) TestPlayDuplicate() {
+	g := yahtzee.NewGameWithOptions(yahtzee.GameOptions{Rounds: 2}, yahtzee.Duplicate)
+	g.Players = []*yahtzee.Player{
+		yahtzee.NewPlayer("Alice"),
+		yahtzee.NewPlayer("Bob"),
+	}
+	g.Seed = 42
+	ts.Require().NoError(ts.store.Save("duplicateID", *g))
+
+	values := func(roll int) []int {
+		res := make([]int, 5)
+		for i := range res {
+			res[i] = yahtzee.DuplicateRoll(42, 0, roll, i, 6)
+		}
+		return res
+	}
+	dices := func() []int {
+		res := []int{}
+		for _, d := range ts.fromStore("duplicateID").Dices {
+			res = append(res, d.Value)
+		}
+		return res
+	}
+
+	// the same dices for every player, whichever dices they keep
+	rr := ts.record(request("POST", "/duplicateID/roll"), asUser("Alice"))
+	ts.Require().Exactly(http.StatusOK, rr.Code)
+	ts.Exactly(values(0), dices())
+
+	rr = ts.record(request("POST", "/duplicateID/lock/0"), asUser("Alice"))
+	ts.Require().Exactly(http.StatusOK, rr.Code)
+	rr = ts.record(request("POST", "/duplicateID/roll"), asUser("Alice"))
+	ts.Require().Exactly(http.StatusOK, rr.Code)
+	alice := append([]int{values(0)[0]}, values(1)[1:]...)
+	ts.Exactly(alice, dices())
+
+	rr = ts.record(request("POST", "/duplicateID/score", "chance"), asUser("Alice"))
+	ts.Require().Exactly(http.StatusOK, rr.Code)
+
+	// the seed would tell the dices of every round
+	var got map[string]interface{}
+	ts.Require().NoError(json.Unmarshal(rr.Body.Bytes(), &got))
+	ts.NotContains(got, "Seed")
+	rr = ts.record(request("GET", "/duplicateID"))
+	ts.Require().Exactly(http.StatusOK, rr.Code)
+	ts.NotContains(rr.Body.String(), "Seed")
+
+	rr = ts.record(request("POST", "/duplicateID/roll"), asUser("Bob"))
+	ts.Require().Exactly(http.StatusOK, rr.Code)
+	ts.Exactly(values(0), dices())
+
+	rr = ts.record(request("POST", "/duplicateID/roll"), asUser("Bob"))
+	ts.Require().Exactly(http.StatusOK, rr.Code)
+	ts.Exactly(values(1), dices())
+
+	rr = ts.record(request("POST", "/duplicateID/score", "ones"), asUser("Bob"))
+	ts.Require().Exactly(http.StatusOK, rr.Code)
+
+	// a new round has other dices
+	saved := ts.fromStore("duplicateID")
+	saved.RollCount = 1
+	for i, d := range saved.Dices {
+		d.Value = []int{6, 6, 6, 6, 6}[i]
+	}
+	saved.Players[0].ScoreSheet[yahtzee.Yahtzee] = 0
+	saved.Players[0].Lines = append(saved.Players[0].Lines, 0)
+	saved.CurrentPlayer = 1
+	ts.Require().NoError(ts.store.Save("duplicateID", *saved))
+
+	rr = ts.record(request("POST", "/duplicateID/score", "yahtzee"), asUser("Bob"))
+	ts.Require().Exactly(http.StatusOK, rr.Code)
+
+	saved = ts.fromStore("duplicateID")
+	ts.True(saved.IsOver())
+
+	got = nil
+	ts.Require().NoError(json.Unmarshal(rr.Body.Bytes(), &got))
+	ts.Exactly(42.0, got["Seed"])
+
+	sum := 0
+	for _, v := range alice {
+		sum += v
+	}
+	ones := 0
+	for _, v := range values(1) {
+		if v == 1 {
+			ones++
+		}
+	}
+	ts.Exactly([]int{sum, 0}, saved.Players[0].Lines)
+	ts.Exactly([]int{ones, 50}, saved.Players[1].Lines)
+
+	standings := saved.Standings()
+	ts.Exactly(yahtzee.User("Bob"), standings[0].User)
+	ts.Exactly([]int{ones - sum, 0}, standings[0].Differences)
+	ts.Exactly([]int{0, -50}, standings[1].Differences)
+}
+
+func (ts *testSuite) TestPlaySimultaneous() {
+	g := yahtzee.NewGameWithOptions(yahtzee.GameOptions{Rounds: 2}, yahtzee.Simultaneous)
+	g.Roller = &scriptedRoller{values: []int{
+		1, 2, 3, 4, 5,
+		6, 6, 6, 6, 6,
+		2, 3, 4, 5,
+		1, 1, 1, 1, 1,
+	}}
+	ts.Require().NoError(ts.store.Save("simultaneousID", *g))
+
+	rr := ts.record(request("POST", "/simultaneousID/join"), asUser("Alice"))
+	ts.Require().Exactly(http.StatusCreated, rr.Code)
+	rr = ts.record(request("POST", "/simultaneousID/join"), asUser("Bob"))
+	ts.Require().Exactly(http.StatusCreated, rr.Code)
+
+	// everyone rolls their own dices
+	eChan := ts.receiveEvents("simultaneousID")
+
+	rr = ts.record(request("POST", "/simultaneousID/roll"), asUser("Alice"))
+	ts.Exactly(http.StatusOK, rr.Code)
+	ts.JSONEq(`{
+		"User": "Alice",
+		"Dices": [
+			{"Value": 1, "Locked": false},
+			{"Value": 2, "Locked": false},
+			{"Value": 3, "Locked": false},
+			{"Value": 4, "Locked": false},
+			{"Value": 5, "Locked": false}
+		],
+		"RollCount": 1
+	}`, rr.Body.String())
+
+	if got := <-eChan; ts.NotNil(got) {
+		ts.Exactly(event.Roll, got.Action)
+		ts.Exactly(yahtzee.User("Alice"), got.Data.(*handler.RollResponse).User)
+	}
+	ts.Require().NoError(ts.event.Unsubscribe("simultaneousID", "simultaneousID"))
+
+	rr = ts.record(request("POST", "/simultaneousID/roll"), asUser("Bob"))
+	ts.Exactly(http.StatusOK, rr.Code)
+
+	rr = ts.record(request("POST", "/simultaneousID/lock/0"), asUser("Alice"))
+	ts.Exactly(http.StatusOK, rr.Code)
+	ts.JSONEq(`{
+		"User": "Alice",
+		"Dices": [
+			{"Value": 1, "Locked": true},
+			{"Value": 2, "Locked": false},
+			{"Value": 3, "Locked": false},
+			{"Value": 4, "Locked": false},
+			{"Value": 5, "Locked": false}
+		]
+	}`, rr.Body.String())
+
+	rr = ts.record(request("POST", "/simultaneousID/roll"), asUser("Alice"))
+	ts.Exactly(http.StatusOK, rr.Code)
+
+	// nobody joins a started game
+	rr = ts.record(request("POST", "/simultaneousID/join"), asUser("Carol"))
+	ts.Exactly(http.StatusBadRequest, rr.Code)
+
+	saved := ts.fromStore("simultaneousID")
+	ts.Exactly(2, saved.Players[0].RollCount)
+	ts.Exactly(1, saved.Players[1].RollCount)
+	ts.Exactly(6, saved.Players[1].Dices[0].Value)
+	ts.False(saved.Players[1].Dices[0].Locked)
+
+	rr = ts.record(request("GET", "/simultaneousID/hints"), asUser("Bob"))
+	ts.Exactly(http.StatusOK, rr.Code)
+	var hints map[yahtzee.Category]int
+	ts.Require().NoError(json.Unmarshal(rr.Body.Bytes(), &hints))
+	ts.Exactly(50, hints[yahtzee.Yahtzee])
+
+	// the round goes on until every player scored
+	rr = ts.record(request("POST", "/simultaneousID/score", "chance"), asUser("Alice"))
+	ts.Exactly(http.StatusOK, rr.Code)
+	rr = ts.record(request("POST", "/simultaneousID/roll"), asUser("Alice"))
+	ts.Exactly(http.StatusBadRequest, rr.Code)
+
+	saved = ts.fromStore("simultaneousID")
+	ts.Exactly(0, saved.Round)
+	ts.Exactly(15, saved.Players[0].ScoreSheet[yahtzee.Chance])
+
+	rr = ts.record(request("POST", "/simultaneousID/score", "yahtzee"), asUser("Bob"))
+	ts.Exactly(http.StatusOK, rr.Code)
+
+	saved = ts.fromStore("simultaneousID")
+	ts.Exactly(1, saved.Round)
+	ts.Exactly(50, saved.Players[1].ScoreSheet[yahtzee.Yahtzee])
+	ts.False(saved.Players[0].Scored)
+	ts.Exactly(0, saved.Players[0].RollCount)
+
+	rr = ts.record(request("POST", "/simultaneousID/roll"), asUser("Alice"))
+	ts.Exactly(http.StatusOK, rr.Code)
+}
+
+func (ts *testSuite) TestPlayHandicap() {
+	rr := ts.record(request("POST", "/", `{"Handicaps":{"Bob":{"Percent":101}}}`))
+	ts.Exactly(http.StatusBadRequest, rr.Code)
+	rr = ts.record(request("POST", "/", `{"Handicaps":{"Bob":{"Points":201}}}`))
+	ts.Exactly(http.StatusBadRequest, rr.Code)
+
+	// the poker dice is decided by the rounds won, not by the points
+	rr = ts.record(request("POST", "/", `{"Features":["poker-dice"],"Handicaps":{"Bob":{"Points":1}}}`))
+	ts.Exactly(http.StatusBadRequest, rr.Code)
+	ts.Exactly(yahtzee.ErrHandicapUnsupported.Error(), strings.TrimSpace(rr.Body.String()))
+
+	rr = ts.record(request("POST", "/", `{"Features":["poker-dice"]}`))
+	ts.Require().Exactly(http.StatusCreated, rr.Code)
+	id := strings.TrimLeft(rr.HeaderMap["Location"][0], "/")
+	rr = ts.record(request("POST", "/"+id+"/join"), asUser("Alice"), withQuery("handicap", "10%"))
+	ts.Exactly(http.StatusBadRequest, rr.Code)
+
+	rr = ts.record(request("POST", "/", `{"Options":{"Rounds":1},"Handicaps":{"Bob":{"Percent":10}}}`))
+	ts.Require().Exactly(http.StatusCreated, rr.Code)
+	id = strings.TrimLeft(rr.HeaderMap["Location"][0], "/")
+
+	rr = ts.record(request("POST", "/"+id+"/join"), asUser("Alice"), withQuery("handicap", "-5"))
+	ts.Exactly(http.StatusBadRequest, rr.Code)
+	rr = ts.record(request("POST", "/"+id+"/join"), asUser("Alice"), withQuery("handicap", "201"))
+	ts.Exactly(http.StatusBadRequest, rr.Code)
+	rr = ts.record(request("POST", "/"+id+"/join"), asUser("Alice"), withQuery("handicap", "20"))
+	ts.Exactly(http.StatusCreated, rr.Code)
+	rr = ts.record(request("POST", "/"+id+"/join"), asUser("Bob"), withQuery("handicap", "50%"))
+	ts.Exactly(http.StatusBadRequest, rr.Code)
+	rr = ts.record(request("POST", "/"+id+"/join"), asUser("Bob"))
+	ts.Exactly(http.StatusCreated, rr.Code)
+	ts.JSONEq(`{"Players": [
+		{"User": "Alice", "ScoreSheet": {}, "Handicap": {"Points": 20}},
+		{"User": "Bob", "ScoreSheet": {}, "Handicap": {"Percent": 10}}
+	]}`, rr.Body.String())
+
+	score := func(user string, values []int) {
+		saved := ts.fromStore(id)
+		saved.RollCount = 1
+		for i, d := range saved.Dices {
+			d.Value = values[i]
+		}
+		ts.Require().NoError(ts.store.Save(id, *saved))
+
+		rr := ts.record(request("POST", "/"+id+"/score", "chance"), asUser(user))
+		ts.Require().Exactly(http.StatusOK, rr.Code)
+	}
+	score("Alice", []int{6, 6, 6, 6, 5})
+
+	rr = ts.record(request("GET", "/"+id+"/results"))
+	ts.Exactly(http.StatusOK, rr.Code)
+	ts.JSONEq(`{
+		"Finished": false,
+		"Standings": [
+			{"User": "Alice", "Total": 49, "Handicap": 20, "Place": 1},
+			{"User": "Bob", "Total": 0, "Place": 2}
+		],
+		"Winners": []
+	}`, rr.Body.String())
+
+	score("Bob", []int{6, 6, 6, 6, 6})
+
+	saved := ts.fromStore(id)
+	ts.Exactly(map[yahtzee.Category]int{
+		yahtzee.Chance:           29,
+		yahtzee.HandicapCategory: 20,
+	}, saved.Players[0].ScoreSheet)
+	ts.Exactly(map[yahtzee.Category]int{
+		yahtzee.Chance:           30,
+		yahtzee.HandicapCategory: 3,
+	}, saved.Players[1].ScoreSheet)
+
+	rr = ts.record(request("GET", "/"+id+"/results"))
+	ts.Exactly(http.StatusOK, rr.Code)
+	ts.JSONEq(`{
+		"Finished": true,
+		"Standings": [
+			{"User": "Alice", "Total": 49, "Handicap": 20, "Place": 1},
+			{"User": "Bob", "Total": 33, "Handicap": 3, "Place": 2}
+		],
+		"Winners": ["Alice"]
+	}`, rr.Body.String())
+}
+
+func (ts *testSuite) TestUndo() {
+	// missing user
+	rr := ts.record(request("POST", "/undoID/undo"))
+	ts.Exactly(http.StatusUnauthorized, rr.Code)
+
+	// game not exists
+	rr = ts.record(request("POST", "/undoID/undo"), asUser("Alice"))
+	ts.Exactly(http.StatusNotFound, rr.Code)
+
+	// nothing to undo
+	g := yahtzee.NewGame()
+	g.Players = []*yahtzee.Player{
+		yahtzee.NewPlayer("Alice"),
+		yahtzee.NewPlayer("Bob"),
+		yahtzee.NewPlayer("Carol"),
+	}
+	g.RollCount = 1
+	g.Dices[0].Value = 6
+	ts.Require().NoError(ts.store.Save("undoID", *g))
+
+	rr = ts.record(request("POST", "/undoID/undo"), asUser("Alice"))
+	ts.Exactly(http.StatusBadRequest, rr.Code)
+
+	// score to undo
+	rr = ts.record(request("POST", "/undoID/score", "sixes"), asUser("Alice"))
+	ts.Require().Exactly(http.StatusOK, rr.Code)
+
+	// approved before requested
+	rr = ts.record(request("POST", "/undoID/undo/approve"), asUser("Bob"))
+	ts.Exactly(http.StatusBadRequest, rr.Code)
+
+	// another players action
+	rr = ts.record(request("POST", "/undoID/undo"), asUser("Bob"))
+	ts.Exactly(http.StatusBadRequest, rr.Code)
+
+	// request
+	eChan := ts.receiveEvents("undoID")
+
+	rr = ts.record(request("POST", "/undoID/undo"), asUser("Alice"))
+	ts.Exactly(http.StatusOK, rr.Code)
+	ts.JSONEq(`{"User": "Alice", "Approvals": []}`, rr.Body.String())
+
+	if got := <-eChan; ts.NotNil(got) {
+		ts.Exactly(event.RequestUndo, got.Action)
+		ts.Exactly(yahtzee.User("Alice"), got.Data.(*handler.UndoResponse).User)
+	}
+	ts.Require().NoError(ts.event.Unsubscribe("undoID", "undoID"))
+
+	// approved by the player asking for it
+	rr = ts.record(request("POST", "/undoID/undo/approve"), asUser("Alice"))
+	ts.Exactly(http.StatusBadRequest, rr.Code)
+
+	// approved by a user not in the game
+	rr = ts.record(request("POST", "/undoID/undo/approve"), asUser("Dave"))
+	ts.Exactly(http.StatusBadRequest, rr.Code)
+
+	// approved by one of the opponents
+	eChan = ts.receiveEvents("undoID")
+
+	rr = ts.record(request("POST", "/undoID/undo/approve"), asUser("Bob"))
+	ts.Exactly(http.StatusOK, rr.Code)
+	ts.JSONEq(`{"User": "Alice", "Approvals": ["Bob"]}`, rr.Body.String())
+
+	if got := <-eChan; ts.NotNil(got) {
+		ts.Exactly(event.ApproveUndo, got.Action)
+	}
+	ts.Require().NoError(ts.event.Unsubscribe("undoID", "undoID"))
+
+	saved := ts.fromStore("undoID")
+	ts.Exactly(6, saved.Players[0].ScoreSheet[yahtzee.Sixes])
+	ts.Exactly(1, saved.CurrentPlayer)
+
+	// approved by every opponent
+	eChan = ts.receiveEvents("undoID")
+
+	rr = ts.record(request("POST", "/undoID/undo/approve"), asUser("Carol"))
+	ts.Exactly(http.StatusOK, rr.Code)
+
+	saved = ts.fromStore("undoID")
+	ts.NotContains(saved.Players[0].ScoreSheet, yahtzee.Sixes)
+	ts.Exactly(0, saved.CurrentPlayer)
+	ts.Exactly(1, saved.RollCount)
+	ts.Exactly(6, saved.Dices[0].Value)
+
+	if got := <-eChan; ts.NotNil(got) {
+		ts.Exactly(event.Undo, got.Action)
+		ts.Exactly(saved.Players, got.Data.(*handler.UndoResponse).Game.Players)
+	}
+	ts.Require().NoError(ts.event.Unsubscribe("undoID", "undoID"))
+
+	// an action can be undone only once
+	rr = ts.record(request("POST", "/undoID/undo"), asUser("Alice"))
+	ts.Exactly(http.StatusBadRequest, rr.Code)
+
+	// declined
+	rr = ts.record(request("POST", "/undoID/lock/2"), asUser("Alice"))
+	ts.Require().Exactly(http.StatusOK, rr.Code)
+	rr = ts.record(request("POST", "/undoID/undo"), asUser("Alice"))
+	ts.Require().Exactly(http.StatusOK, rr.Code)
+
+	eChan = ts.receiveEvents("undoID")
+
+	rr = ts.record(request("POST", "/undoID/undo/decline"), asUser("Carol"))
+	ts.Exactly(http.StatusOK, rr.Code)
+
+	if got := <-eChan; ts.NotNil(got) {
+		ts.Exactly(event.DeclineUndo, got.Action)
+	}
+	ts.Require().NoError(ts.event.Unsubscribe("undoID", "undoID"))
+
+	ts.True(ts.fromStore("undoID").Dices[2].Locked)
+	rr = ts.record(request("POST", "/undoID/undo/approve"), asUser("Bob"))
+	ts.Exactly(http.StatusBadRequest, rr.Code)
+
+	// rolling drops the undo
+	rr = ts.record(request("POST", "/undoID/lock/2"), asUser("Alice"))
+	ts.Require().Exactly(http.StatusOK, rr.Code)
+	rr = ts.record(request("POST", "/undoID/roll"), asUser("Alice"))
+	ts.Require().Exactly(http.StatusOK, rr.Code)
+
+	rr = ts.record(request("POST", "/undoID/undo"), asUser("Alice"))
+	ts.Exactly(http.StatusBadRequest, rr.Code)
+
+	// no opponents to approve
+	g = yahtzee.NewGame()
+	g.Players = []*yahtzee.Player{
+		yahtzee.NewPlayer("Alice"),
+	}
+	g.RollCount = 1
+	ts.Require().NoError(ts.store.Save("undoID", *g))
+
+	rr = ts.record(request("POST", "/undoID/lock/0"), asUser("Alice"))
+	ts.Require().Exactly(http.StatusOK, rr.Code)
+	ts.True(ts.fromStore("undoID").Dices[0].Locked)
+
+	rr = ts.record(request("POST", "/undoID/undo"), asUser("Alice"))
+	ts.Exactly(http.StatusOK, rr.Code)
+	ts.False(ts.fromStore("undoID").Dices[0].Locked)
+}
+
+func (ts *testSuite) TestPlayBots() {
+	delay := handler.BotDelay
+	handler.BotDelay = time.Millisecond
+	defer func() { handler.BotDelay = delay }()
+
+	rr := ts.record(request("POST", "/", `{"Options":{"Rounds":1}}`), asUser("Alice"))
+	ts.Require().Exactly(http.StatusCreated, rr.Code)
+	id := strings.TrimLeft(rr.HeaderMap["Location"][0], "/")
+	ts.Exactly(yahtzee.User("Alice"), ts.fromStore(id).Creator)
+
+	// only the creator adds bots
+	rr = ts.record(request("POST", "/"+id+"/bots"), asUser("Bob"))
+	ts.Exactly(http.StatusForbidden, rr.Code)
+
+	rr = ts.record(request("POST", "/"+id+"/bots"), withQuery("difficulty", "genius"), asUser("Alice"))
+	ts.Exactly(http.StatusBadRequest, rr.Code)
+
+	rr = ts.record(request("POST", "/"+id+"/bots"), withQuery("difficulty", "smart"), asUser("Alice"))
+	ts.Exactly(http.StatusCreated, rr.Code)
+	ts.JSONEq(`{"Players": [
+		{"User": "Bot 1", "ScoreSheet": {}, "Bot": "smart"}
+	]}`, rr.Body.String())
+
+	// the bot waits for the game to start, so everybody can join
+	rr = ts.record(request("POST", "/"+id+"/join"), asUser("Alice"))
+	ts.Require().Exactly(http.StatusCreated, rr.Code)
+	time.Sleep(20 * time.Millisecond)
+	ts.Exactly(0, ts.fromStore(id).RollCount)
+
+	rr = ts.record(request("POST", "/"+id+"/join"), asUser("Bob"))
+	ts.Require().Exactly(http.StatusCreated, rr.Code)
+	time.Sleep(20 * time.Millisecond)
+	ts.Exactly(0, ts.fromStore(id).RollCount)
+
+	// only the creator starts the game
+	rr = ts.record(request("POST", "/"+id+"/start"), asUser("Bob"))
+	ts.Exactly(http.StatusForbidden, rr.Code)
+
+	eChan := ts.receiveEvents(id)
+
+	rr = ts.record(request("POST", "/"+id+"/start"), asUser("Alice"))
+	ts.Require().Exactly(http.StatusOK, rr.Code)
+
+	if got := <-eChan; ts.NotNil(got) {
+		ts.Exactly(event.Start, got.Action)
+	}
+	if got := <-eChan; ts.NotNil(got) {
+		ts.Exactly(event.Roll, got.Action)
+		ts.Exactly(yahtzee.User("Bot 1"), *got.User)
+	}
+	for got := <-eChan; ts.NotNil(got); got = <-eChan {
+		ts.Exactly(yahtzee.User("Bot 1"), *got.User)
+		if got.Action == event.Score {
+			break
+		}
+		ts.Contains([]event.Type{event.Roll, event.Lock}, got.Action)
+	}
+	ts.Require().NoError(ts.event.Unsubscribe(id, id))
+
+	saved := ts.fromStore(id)
+	ts.Exactly(1, saved.CurrentPlayer)
+	ts.Len(saved.Players[0].ScoreSheet, 1)
+
+	rr = ts.record(request("POST", "/"+id+"/start"), asUser("Alice"))
+	ts.Exactly(http.StatusBadRequest, rr.Code)
+	rr = ts.record(request("POST", "/"+id+"/join"), asUser("Carol"))
+	ts.Exactly(http.StatusBadRequest, rr.Code)
+
+	for _, u := range []string{"Alice", "Bob"} {
+		rr = ts.record(request("POST", "/"+id+"/roll"), asUser(u))
+		ts.Exactly(http.StatusOK, rr.Code)
+		rr = ts.record(request("POST", "/"+id+"/score", "chance"), asUser(u))
+		ts.Exactly(http.StatusOK, rr.Code)
+	}
+	ts.True(ts.fromStore(id).IsOver())
+
+	// in a simultaneous game the bots play after the first roll of a human
+	rr = ts.record(request("POST", "/", `{"Features":["simultaneous"],"Options":{"Rounds":1}}`), asUser("Alice"))
+	ts.Require().Exactly(http.StatusCreated, rr.Code)
+	id = strings.TrimLeft(rr.HeaderMap["Location"][0], "/")
+	rr = ts.record(request("POST", "/"+id+"/join"), asUser("Alice"))
+	ts.Require().Exactly(http.StatusCreated, rr.Code)
+	rr = ts.record(request("POST", "/"+id+"/bots"), asUser("Alice"))
+	ts.Require().Exactly(http.StatusCreated, rr.Code)
+	time.Sleep(20 * time.Millisecond)
+	ts.Exactly(0, ts.fromStore(id).Players[1].RollCount)
+
+	rr = ts.record(request("POST", "/"+id+"/join"), asUser("Bob"))
+	ts.Require().Exactly(http.StatusCreated, rr.Code)
+
+	eChan = ts.receiveEvents(id)
+
+	rr = ts.record(request("POST", "/"+id+"/roll"), asUser("Bob"))
+	ts.Require().Exactly(http.StatusOK, rr.Code)
+
+	for got := <-eChan; ts.NotNil(got); got = <-eChan {
+		if got.Action == event.Score {
+			ts.Exactly(yahtzee.User("Bot 2"), *got.User)
+			break
+		}
+	}
+	ts.Require().NoError(ts.event.Unsubscribe(id, id))
+	ts.True(ts.fromStore(id).Players[1].Scored)
+
+	// optimal bots need the solver
+	rr = ts.record(request("POST", "/", `["triple"]`), asUser("Alice"))
+	ts.Require().Exactly(http.StatusCreated, rr.Code)
+	id = strings.TrimLeft(rr.HeaderMap["Location"][0], "/")
+	rr = ts.record(request("POST", "/"+id+"/bots"), withQuery("difficulty", "optimal"), asUser("Alice"))
+	ts.Exactly(http.StatusBadRequest, rr.Code)
+
+	// optimal bots need a solver table
+	rr = ts.record(request("POST", "/"), asUser("Alice"))
+	ts.Require().Exactly(http.StatusCreated, rr.Code)
+	id = strings.TrimLeft(rr.HeaderMap["Location"][0], "/")
+	rr = ts.record(request("POST", "/"+id+"/bots"), withQuery("difficulty", "optimal"), asUser("Alice"))
+	ts.Exactly(http.StatusBadRequest, rr.Code)
+	ts.Exactly(solver.ErrNoTable.Error(), strings.TrimSpace(rr.Body.String()))
+
+	// without its table an optimal bot plays smart
+	saved = ts.fromStore(id)
+	ts.Require().NoError(saved.AddBot("Robo", yahtzee.Optimal, ""))
+	ts.Require().NoError(ts.store.Save(id, *saved))
+
+	rr = ts.record(request("POST", "/"+id+"/join"), asUser("Alice"))
+	ts.Require().Exactly(http.StatusCreated, rr.Code)
+
+	eChan = ts.receiveEvents(id)
+
+	rr = ts.record(request("POST", "/"+id+"/start"), asUser("Alice"))
+	ts.Require().Exactly(http.StatusOK, rr.Code)
+
+	for got := <-eChan; ts.NotNil(got); got = <-eChan {
+		if got.Action == event.Score {
+			ts.Exactly(yahtzee.User("Robo"), *got.User)
+			break
+		}
+	}
+	ts.Require().NoError(ts.event.Unsubscribe(id, id))
+	ts.Len(ts.fromStore(id).Players[0].ScoreSheet, 1)
+
+	rr = ts.record(request("POST", "/", `{"Options":{"Rounds":1}}`), asUser("Alice"))
+	ts.Require().Exactly(http.StatusCreated, rr.Code)
+	id = strings.TrimLeft(rr.HeaderMap["Location"][0], "/")
+	rr = ts.record(request("POST", "/"+id+"/join"), asUser("Alice"))
+	ts.Require().Exactly(http.StatusCreated, rr.Code)
+	rr = ts.record(request("POST", "/"+id+"/bots"), withQuery("difficulty", "optimal"), withQuery("name", "Robo"), asUser("Alice"))
+	ts.Exactly(http.StatusCreated, rr.Code)
+
+	rr = ts.record(request("POST", "/"+id+"/roll"), asUser("Alice"))
+	ts.Exactly(http.StatusOK, rr.Code)
+
+	eChan = ts.receiveEvents(id)
+
+	rr = ts.record(request("POST", "/"+id+"/score", "chance"), asUser("Alice"))
+	ts.Exactly(http.StatusOK, rr.Code)
+
+	for got := <-eChan; ts.NotNil(got); got = <-eChan {
+		if got.Action == event.Score && *got.User == "Robo" {
+			ts.NotNil(got.Data.(*handler.ScoreResponse).Standings)
+			break
+		}
+	}
+	ts.Require().NoError(ts.event.Unsubscribe(id, id))
+
+	saved = ts.fromStore(id)
+	ts.True(saved.IsOver())
+	ts.Len(saved.Players[1].ScoreSheet, 1)
+}
+
+func (ts *testSuite) TestAnalysis() {
+	// game not exists
+	rr := ts.record(request("GET", "/analysisID/analysis"))
+	ts.Exactly(http.StatusNotFound, rr.Code)
+
+	g := yahtzee.NewGameWithOptions(yahtzee.GameOptions{Rounds: 1})
+	g.Players = []*yahtzee.Player{
+		yahtzee.NewPlayer("Alice"),
+	}
+	g.Roller = &scriptedRoller{values: []int{6, 6, 6, 2, 3, 6, 6}}
+	ts.Require().NoError(ts.store.Save("analysisID", *g))
+
+	rr = ts.record(request("POST", "/analysisID/roll"), asUser("Alice"))
+	ts.Require().Exactly(http.StatusOK, rr.Code)
+	for _, dice := range []string{"0", "1", "2"} {
+		rr = ts.record(request("POST", "/analysisID/lock/"+dice), asUser("Alice"))
+		ts.Require().Exactly(http.StatusOK, rr.Code)
+	}
+	rr = ts.record(request("POST", "/analysisID/roll"), asUser("Alice"))
+	ts.Require().Exactly(http.StatusOK, rr.Code)
+
+	// game not over
+	rr = ts.record(request("GET", "/analysisID/analysis"))
+	ts.Exactly(http.StatusBadRequest, rr.Code)
+
+	// the undone score is not analyzed
+	rr = ts.record(request("POST", "/analysisID/score", "yahtzee"), asUser("Alice"))
+	ts.Require().Exactly(http.StatusOK, rr.Code)
+	rr = ts.record(request("POST", "/analysisID/undo"), asUser("Alice"))
+	ts.Require().Exactly(http.StatusOK, rr.Code)
+
+	rr = ts.record(request("POST", "/analysisID/score", "chance"), asUser("Alice"))
+	ts.Require().Exactly(http.StatusOK, rr.Code)
+
+	rr = ts.record(request("GET", "/analysisID/analysis"))
+	ts.Require().Exactly(http.StatusOK, rr.Code)
+
+	var got analysis.Analysis
+	ts.Require().NoError(json.Unmarshal(rr.Body.Bytes(), &got))
+	ts.Require().Len(got.Players, 1)
+	alice := got.Players[0]
+	ts.Exactly(yahtzee.User("Alice"), alice.User)
+	ts.Require().Len(alice.Turns, 1)
+
+	turn := alice.Turns[0]
+	ts.Exactly([]int{6, 6, 6, 6, 6}, turn.Dices)
+	ts.Exactly(yahtzee.Chance, turn.Category)
+	ts.Exactly(yahtzee.Yahtzee, turn.Best)
+	if ts.Len(turn.Holds, 1) {
+		ts.Exactly([]int{6, 6, 6, 2, 3}, turn.Holds[0].Dices)
+		ts.Exactly([]int{0, 1, 2}, turn.Holds[0].Kept)
+		ts.Exactly([]int{0, 1, 2}, turn.Holds[0].Best)
+		ts.InDelta(0, turn.Holds[0].Lost, 1e-9)
+	}
+	ts.InDelta(20, turn.Lost, 1e-9)
+
+	ts.InDelta(20, alice.Lost, 1e-9)
+	ts.Greater(alice.Luck, 0.0)
+	ts.InDelta(30, alice.Expected+alice.Luck-alice.Lost, 1e-9)
+	ts.InDelta(100*(1-20/alice.Expected), alice.Skill, 1e-9)
+
+	// no solver table
+	g = yahtzee.NewGame()
+	g.Players = []*yahtzee.Player{
+		yahtzee.NewPlayer("Alice"),
+	}
+	g.Round = g.Rounds()
+	ts.Require().NoError(ts.store.Save("analysisID", *g))
+
+	rr = ts.record(request("GET", "/analysisID/analysis"))
+	ts.Exactly(http.StatusServiceUnavailable, rr.Code)
+
+	// unsupported features
+	g = yahtzee.NewGame(yahtzee.Triple)
+	g.Players = []*yahtzee.Player{
+		yahtzee.NewPlayer("Alice"),
+	}
+	g.Round = g.Rounds()
+	ts.Require().NoError(ts.store.Save("analysisID", *g))
+
+	rr = ts.record(request("GET", "/analysisID/analysis"))
+	ts.Exactly(http.StatusBadRequest, rr.Code)
+}
+
+func (ts *testSuite) TestWS() {
+	server := httptest.NewServer(ts.handler)
+	defer server.Close()
+	baseUrl := "ws" + strings.TrimPrefix(server.URL, "http")
+
+	ts.Require().NoError(ts.store.Save("wsID", *yahtzee.NewGame()))
+
+	ws, _, err := websocket.DefaultDialer.Dial(baseUrl+"/wsID/ws", nil)
+	if !ts.NoError(err) {
+		return
+	}
+	defer ws.Close()
+
+	ts.event.Emit("wsID", yahtzee.NewUser("Alice"), event.AddPlayer, nil)
+
+	_, p, err := ws.ReadMessage()
+	if ts.NoError(err) {
 		ts.JSONEq(`{
 				"User": "Alice",
 				"Action": "add-player",
@@ -2480,8 +4548,114 @@ func (ts *testSuite) TestWS() {
 func (ts *testSuite) TestFeatures() {
 	rr := ts.record(request("GET", "/features"))
 	ts.Exactly(http.StatusOK, rr.Code)
-	features, _ := json.Marshal(yahtzee.Features())
-	ts.JSONEq(string(features), rr.Body.String())
+	var got []yahtzee.FeatureMetadata
+	ts.Require().NoError(json.Unmarshal(rr.Body.Bytes(), &got))
+
+	ids := []yahtzee.Feature{}
+	byID := map[yahtzee.Feature]yahtzee.FeatureMetadata{}
+	for _, f := range got {
+		ids = append(ids, f.ID)
+		byID[f.ID] = f
+	}
+	ts.Exactly(yahtzee.Features(), ids)
+
+	official := byID[yahtzee.Official]
+	ts.Exactly("Official", official.DisplayName)
+	ts.NotEmpty(official.Description)
+	ts.NotContains(official.Conflicts, yahtzee.YahtzeeBonus)
+	ts.Contains(byID[yahtzee.YachtRules].Conflicts, yahtzee.YahtzeeBonus)
+	ts.Exactly([]yahtzee.Feature{yahtzee.SixDice}, byID[yahtzee.MaxiRules].Implies)
+	ts.Exactly([]yahtzee.Feature{}, byID[yahtzee.Ordered].Conflicts)
+}
+
+func (ts *testSuite) TestCreateWithInvalidFeatures() {
+	badInputs := []struct {
+		features string
+		error    string
+	}{
+		{`["six-dice","no-such-feature"]`, `unknown feature: "no-such-feature"`},
+		{`["yahtzee-bonus","yacht"]`, `conflicting features: "yahtzee-bonus" can't be played with "yacht"`},
+		{`["yacht","generala"]`, `conflicting features: "yacht" can't be played with "generala"`},
+		{`["poker-dice","maxi"]`, `conflicting features: "poker-dice" can't be played with "maxi"`},
+		{`["teams","duplicate"]`, `conflicting features: "teams" can't be played with "duplicate"`},
+	}
+	for _, tc := range badInputs {
+		rr := ts.record(request("POST", "/", tc.features))
+		ts.Exactly(http.StatusBadRequest, rr.Code, "when %s", tc.features)
+		ts.Exactly(tc.error, strings.TrimSpace(rr.Body.String()))
+
+		rr = ts.record(request("POST", "/", `{"Features":`+tc.features+`}`))
+		ts.Exactly(http.StatusBadRequest, rr.Code, "when %s", tc.features)
+
+		rr = ts.record(request("POST", "/score", `{"Dices":[1,2,3,4,5],"Features":`+tc.features+`}`))
+		ts.Exactly(http.StatusBadRequest, rr.Code, "when %s", tc.features)
+		ts.Exactly(tc.error, strings.TrimSpace(rr.Body.String()))
+	}
+
+	// duplicated and implied features
+	rr := ts.record(request("POST", "/", `["maxi","saved-rolls","maxi"]`))
+	ts.Exactly(http.StatusCreated, rr.Code)
+	if ts.Contains(rr.HeaderMap, "Location") && ts.Len(rr.HeaderMap["Location"], 1) {
+		created := ts.fromStore(strings.TrimLeft(rr.HeaderMap["Location"][0], "/"))
+		ts.Exactly([]yahtzee.Feature{yahtzee.MaxiRules, yahtzee.SixDice, yahtzee.SavedRolls}, created.Features)
+	}
+}
+
+func (ts *testSuite) TestRegisteredFeatures() {
+	doubleChance := yahtzee.Feature("test-double-chance")
+	yahtzee.RegisterFeature(doubleChance, yahtzee.FeatureDescriptor{
+		ScoreActions: yahtzee.Scorers{
+			yahtzee.Chance: func(game *yahtzee.Game) int {
+				return 2 * yahtzee.DefaultChance(game)
+			},
+		},
+	})
+	lateFullHouse := yahtzee.Feature("test-late-full-house")
+	earlyFullHouse := yahtzee.Feature("test-early-full-house")
+	yahtzee.RegisterFeature(lateFullHouse, yahtzee.FeatureDescriptor{
+		ScoreActions: yahtzee.Scorers{
+			yahtzee.FullHouse: func(*yahtzee.Game) int { return 10 },
+		},
+		After: []yahtzee.Feature{earlyFullHouse},
+	})
+	yahtzee.RegisterFeature(earlyFullHouse, yahtzee.FeatureDescriptor{
+		ScoreActions: yahtzee.Scorers{
+			yahtzee.FullHouse: func(*yahtzee.Game) int { return 20 },
+		},
+	})
+
+	ts.Exactly(yahtzee.ErrFeatureRegistered, yahtzee.RegisterFeature(doubleChance, yahtzee.FeatureDescriptor{}))
+	ts.Exactly(yahtzee.ErrInvalidFeature, yahtzee.RegisterFeature("", yahtzee.FeatureDescriptor{}))
+
+	rr := ts.record(request("GET", "/features"))
+	ts.Exactly(http.StatusOK, rr.Code)
+	var got []yahtzee.FeatureMetadata
+	ts.Require().NoError(json.Unmarshal(rr.Body.Bytes(), &got))
+	ts.Contains(got, yahtzee.FeatureMetadata{
+		ID:        doubleChance,
+		Conflicts: []yahtzee.Feature{},
+		Implies:   []yahtzee.Feature{},
+	})
+	ts.Contains(yahtzee.Features(), lateFullHouse)
+
+	cases := []struct {
+		features string
+		category yahtzee.Category
+		value    int
+	}{
+		{`["test-double-chance"]`, yahtzee.Chance, 52},
+		{`["test-double-chance"]`, yahtzee.FullHouse, 25},
+		{`["test-early-full-house"]`, yahtzee.FullHouse, 20},
+		{`["test-early-full-house","test-late-full-house"]`, yahtzee.FullHouse, 10},
+		{`["test-late-full-house","test-early-full-house"]`, yahtzee.FullHouse, 10},
+	}
+	for _, tc := range cases {
+		rr := ts.record(request("POST", "/score", `{"Dices":[6,6,6,4,4],"Features":`+tc.features+`}`))
+		ts.Require().Exactly(http.StatusOK, rr.Code)
+		var got map[yahtzee.Category]int
+		ts.Require().NoError(json.Unmarshal(rr.Body.Bytes(), &got))
+		ts.Exactly(tc.value, got[tc.category], "with %s", tc.features)
+	}
 }
 
 func (ts *testSuite) record(
@@ -2525,6 +4699,18 @@ func (ts *testSuite) receiveEvents(id string) chan *event.Event {
 	return res
 }
 
+type scriptedRoller struct {
+	values []int
+	faces  []int
+}
+
+func (r *scriptedRoller) Roll(faces int) int {
+	r.faces = append(r.faces, faces)
+	v := r.values[0]
+	r.values = r.values[1:]
+	return v
+}
+
 func request(method string, url string, body ...interface{}) *http.Request {
 	var bodyReader io.Reader
 
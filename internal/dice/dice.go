@@ -0,0 +1,204 @@
+// Package dice enumerates the combinations of the dices, the holds that can be
+// kept from them and the combinations the holds can become after a roll. The
+// expected values of the game and the solver are calculated over them.
+package dice
+
+// Counts is a combination of dices: the number of dices with each value.
+type Counts []int
+
+// Outcome is a combination a hold can become after a roll.
+type Outcome struct {
+	State       int
+	Probability float64
+}
+
+// Combinations has the combinations of `n` dices with `faces` faces.
+type Combinations struct {
+	Faces int
+
+	// States has every combination of the dices, and Index has the index of
+	// each by its key
+	States []Counts
+	Index  map[uint64]int
+
+	// Holds has every combination that can be kept for a roll, and HoldIndex
+	// has the index of each by its key
+	Holds     []Counts
+	HoldIndex map[uint64]int
+	EmptyHold int
+
+	// SubHolds has the holds that can be kept from each state
+	SubHolds [][]int
+
+	// Outcomes has the states a hold can become after the roll, with their
+	// probabilities
+	Outcomes [][]Outcome
+
+	base uint64
+}
+
+// New returns the combinations of `n` dices with `faces` faces.
+func New(n, faces int) *Combinations {
+	c := &Combinations{
+		Faces:     faces,
+		Index:     map[uint64]int{},
+		HoldIndex: map[uint64]int{},
+		base:      uint64(n + 1),
+	}
+
+	c.States = multisets(n, faces)
+	for i, s := range c.States {
+		c.Index[c.Key(s)] = i
+	}
+
+	for k := 0; k <= n; k++ {
+		for _, h := range multisets(k, faces) {
+			c.HoldIndex[c.Key(h)] = len(c.Holds)
+			c.Holds = append(c.Holds, h)
+		}
+	}
+	c.EmptyHold = c.HoldIndex[c.Key(make(Counts, faces))]
+
+	c.SubHolds = make([][]int, len(c.States))
+	for i, s := range c.States {
+		for _, h := range subsets(s) {
+			c.SubHolds[i] = append(c.SubHolds[i], c.HoldIndex[c.Key(h)])
+		}
+	}
+
+	rolls := make([][]Counts, n+1)
+	probabilities := make([][]float64, n+1)
+	for k := 0; k <= n; k++ {
+		rolls[k] = multisets(k, faces)
+		for _, r := range rolls[k] {
+			probabilities[k] = append(probabilities[k], probability(r, faces))
+		}
+	}
+	c.Outcomes = make([][]Outcome, len(c.Holds))
+	for i, h := range c.Holds {
+		k := n - size(h)
+		for j, r := range rolls[k] {
+			s := make(Counts, faces)
+			for v := range s {
+				s[v] = h[v] + r[v]
+			}
+			c.Outcomes[i] = append(c.Outcomes[i], Outcome{
+				State:       c.Index[c.Key(s)],
+				Probability: probabilities[k][j],
+			})
+		}
+	}
+
+	return c
+}
+
+// Key identifies the combination or the hold.
+func (c *Combinations) Key(s Counts) uint64 {
+	var res uint64
+	for _, count := range s {
+		res = res*c.base + uint64(count)
+	}
+	return res
+}
+
+// Of returns the combination of the dice values, and false when a value is
+// not a face of the dices.
+func (c *Combinations) Of(values []int) (Counts, bool) {
+	res := make(Counts, c.Faces)
+	for _, v := range values {
+		if v < 1 || v > c.Faces {
+			return nil, false
+		}
+		res[v-1]++
+	}
+	return res, true
+}
+
+// StateOf returns the index of the combination of the dice values.
+func (c *Combinations) StateOf(values []int) (int, bool) {
+	s, ok := c.Of(values)
+	if !ok {
+		return 0, false
+	}
+	i, ok := c.Index[c.Key(s)]
+	return i, ok
+}
+
+// Count returns how many hold and roll pairs there are for `n` dices with
+// `faces` faces, stopping at `limit`.
+func Count(n, faces, limit int) int {
+	res := 0
+	for k := 0; k <= n && res <= limit; k++ {
+		res += binomial(k+faces-1, k) * binomial(n-k+faces-1, n-k)
+	}
+	return res
+}
+
+func binomial(n, k int) int {
+	res := 1
+	for i := 1; i <= k; i++ {
+		res = res * (n - k + i) / i
+	}
+	return res
+}
+
+// multisets returns every combination of `n` dices with `faces` faces.
+func multisets(n, faces int) []Counts {
+	res := []Counts{}
+	current := make(Counts, faces)
+	var fill func(v, left int)
+	fill = func(v, left int) {
+		if v == faces-1 {
+			current[v] = left
+			res = append(res, append(Counts{}, current...))
+			return
+		}
+		for c := left; c >= 0; c-- {
+			current[v] = c
+			fill(v+1, left-c)
+		}
+	}
+	fill(0, n)
+	return res
+}
+
+// subsets returns every combination that can be kept from the combination.
+func subsets(s Counts) []Counts {
+	res := []Counts{}
+	current := make(Counts, len(s))
+	var fill func(v int)
+	fill = func(v int) {
+		if v == len(s) {
+			res = append(res, append(Counts{}, current...))
+			return
+		}
+		for c := 0; c <= s[v]; c++ {
+			current[v] = c
+			fill(v + 1)
+		}
+	}
+	fill(0)
+	return res
+}
+
+// probability returns the probability of rolling the combination.
+func probability(s Counts, faces int) float64 {
+	res := 1.0
+	for i := 1; i <= size(s); i++ {
+		res *= float64(i) / float64(faces)
+	}
+	for _, c := range s {
+		for i := 2; i <= c; i++ {
+			res /= float64(i)
+		}
+	}
+	return res
+}
+
+func size(s Counts) int {
+	res := 0
+	for _, c := range s {
+		res += c
+	}
+	return res
+}
@@ -1,10 +1,5 @@
 package yahtzee
 
-var (
-	// NumberOfDices shows how many dices are used for a game.
-	NumberOfDices int = 5
-)
-
 // Dice represents a dice you use for the Game.
 type Dice struct {
 	// Value is the number on the face of the dice
@@ -12,6 +7,9 @@ type Dice struct {
 
 	// Locked shows if the dice will roll or not
 	Locked bool
+
+	// Face is the symbol on the face of the dice when the rules name the faces
+	Face string `json:",omitempty"`
 }
 
 // Category represents the formations players try to roll.
@@ -27,6 +25,13 @@ const (
 	Sixes  Category = "sixes"
 	Bonus  Category = "bonus"
 
+	Sevens  Category = "sevens"
+	Eights  Category = "eights"
+	Nines   Category = "nines"
+	Tens    Category = "tens"
+	Elevens Category = "elevens"
+	Twelves Category = "twelves"
+
 	ThreeOfAKind  Category = "three-of-a-kind"
 	FourOfAKind   Category = "four-of-a-kind"
 	FullHouse     Category = "full-house"
@@ -35,6 +40,28 @@ const (
 	Yahtzee       Category = "yahtzee"
 	Chance        Category = "chance"
 	ChanceBonus   Category = "chance-bonus"
+
+	LittleStraight Category = "little-straight"
+	BigStraight    Category = "big-straight"
+	Choice         Category = "choice"
+	Yacht          Category = "yacht"
+
+	Escalera Category = "escalera"
+	Full     Category = "full"
+	Poker    Category = "poker"
+	Generala Category = "generala"
+
+	OnePair      Category = "one-pair"
+	TwoPairs     Category = "two-pairs"
+	ThreePairs   Category = "three-pairs"
+	FiveOfAKind  Category = "five-of-a-kind"
+	FullStraight Category = "full-straight"
+	Castle       Category = "castle"
+	Tower        Category = "tower"
+	MaxiYahtzee  Category = "maxi-yahtzee"
+
+	Hand      Category = "hand"
+	RoundsWon Category = "rounds-won"
 )
 
 func Categories() []Category {
@@ -55,6 +82,99 @@ func Categories() []Category {
 	}
 }
 
+// UpperCategories returns the upper section categories of a game played with
+// dices having `faces` faces.
+func UpperCategories(faces int) []Category {
+	return []Category{
+		Ones,
+		Twos,
+		Threes,
+		Fours,
+		Fives,
+		Sixes,
+		Sevens,
+		Eights,
+		Nines,
+		Tens,
+		Elevens,
+		Twelves,
+	}[:faces]
+}
+
+// UpperCategory returns the upper section category counting the dices with
+// the `value`.
+func UpperCategory(value int) Category {
+	return UpperCategories(MaxFaces)[value-1]
+}
+
+// YachtCategories returns the categories of the classic Yacht rules.
+func YachtCategories() []Category {
+	return []Category{
+		Ones,
+		Twos,
+		Threes,
+		Fours,
+		Fives,
+		Sixes,
+		FullHouse,
+		FourOfAKind,
+		LittleStraight,
+		BigStraight,
+		Choice,
+		Yacht,
+	}
+}
+
+// GeneralaCategories returns the categories of the Generala rules.
+func GeneralaCategories() []Category {
+	return []Category{
+		Ones,
+		Twos,
+		Threes,
+		Fours,
+		Fives,
+		Sixes,
+		Escalera,
+		Full,
+		Poker,
+		Generala,
+	}
+}
+
+// MaxiCategories returns the categories of the Maxi Yahtzee rules.
+func MaxiCategories() []Category {
+	return []Category{
+		Ones,
+		Twos,
+		Threes,
+		Fours,
+		Fives,
+		Sixes,
+		OnePair,
+		TwoPairs,
+		ThreePairs,
+		ThreeOfAKind,
+		FourOfAKind,
+		FiveOfAKind,
+		SmallStraight,
+		LargeStraight,
+		FullStraight,
+		FullHouse,
+		Castle,
+		Tower,
+		Chance,
+		MaxiYahtzee,
+	}
+}
+
+// PokerDiceCategories returns the categories of the Poker Dice rules. Every
+// round is played for the hand.
+func PokerDiceCategories() []Category {
+	return []Category{
+		Hand,
+	}
+}
+
 // Player contains all data representing a player.
 type Player struct {
 	// User who plays
@@ -62,6 +182,57 @@ type Player struct {
 
 	// ScoreSheet keeps the scores of the player
 	ScoreSheet map[Category]int
+
+	// SavedRolls shows how many unused rolls the player has from the previous
+	// turns.
+	SavedRolls int `json:",omitempty"`
+
+	// Columns keeps the scores of the player when the game is played with
+	// multiple score sheet columns. The ScoreSheet is not used then.
+	Columns []map[Category]int `json:",omitempty"`
+
+	// Hands keeps the hands the player showed in each round when the rounds
+	// are decided by comparing hands.
+	Hands []PokerHand `json:",omitempty"`
+
+	// Team is the name of the player's team in the Teams mode. The player
+	// scores into the score sheet of the team then.
+	Team string `json:",omitempty"`
+
+	// Lines keeps the points the player scored in each round of a Duplicate
+	// game.
+	Lines []int `json:",omitempty"`
+
+	// Dices has the own dices of the player in a Simultaneous game
+	Dices []*Dice `json:",omitempty"`
+
+	// RollCount shows how many times the player rolled in the round of a
+	// Simultaneous game
+	RollCount int `json:",omitempty"`
+
+	// Scored shows if the player scored in the round of a Simultaneous game
+	Scored bool `json:",omitempty"`
+
+	// Handicap gives extra points to the player at the end of the game
+	Handicap *Handicap `json:",omitempty"`
+
+	// Bot is the difficulty of the player when the server plays for it
+	Bot Difficulty `json:",omitempty"`
+}
+
+// Sheet returns the score sheet in the `column`. Players without columns have
+// only the ScoreSheet.
+func (p *Player) Sheet(column int) map[Category]int {
+	return sheetIn(p.ScoreSheet, p.Columns, column)
+}
+
+// sheetIn returns the score sheet in the `column`: the single sheet when there
+// are no columns.
+func sheetIn(sheet map[Category]int, columns []map[Category]int, column int) map[Category]int {
+	if len(columns) == 0 {
+		return sheet
+	}
+	return columns[column]
 }
 
 // NewPlayer returns a new named player with an empty score sheet.
@@ -77,12 +248,28 @@ type Game struct {
 	// Players has the list of the players in an ordered manner
 	Players []*Player
 
+	// Teams has the teams in the order they take turns in the Teams mode
+	Teams []*Team `json:",omitempty"`
+
 	// Dices has the dices the game played with
 	Dices []*Dice
 
 	// Features has the features to play the game with
 	Features []Feature
 
+	// Options has the house rules of the game
+	Options *GameOptions `json:",omitempty"`
+
+	// Handicaps has the handicaps the creator of the game set for the users
+	Handicaps map[User]Handicap `json:",omitempty"`
+
+	// Creator is the user who created the game
+	Creator User `json:",omitempty"`
+
+	// Started is set when the game was started before anybody acted, so the
+	// bots can take the first turns
+	Started bool `json:",omitempty"`
+
 	// Round shows how many rounds were passed already.
 	Round int
 
@@ -92,6 +279,22 @@ type Game struct {
 	// RollCount shows how many times the dices were rolled for the current user in this round.
 	RollCount int
 
+	// Winner is set when a player won the game before the last round.
+	Winner User `json:",omitempty"`
+
+	// Column is the score sheet column the current player scores into.
+	Column int `json:"-"`
+
+	// Seed initializes the dice roller of the game. A game played with the same
+	// seed and the same actions gives the same dices.
+	Seed int64 `json:",omitempty"`
+
+	// RollCounter shows how many dice values were produced with the seed.
+	RollCounter int `json:",omitempty"`
+
+	// Roller produces the dice values. The seeded roller is used when not set.
+	Roller Roller `json:"-"`
+
 	Scorer *Score `json:"-"`
 
 	Context map[string]interface{} `json:"-"`
@@ -102,89 +305,95 @@ type Feature string
 
 // Available features
 const (
-	SixDice      Feature = "six-dice"
-	TheChance    Feature = "the-chance"
-	YahtzeeBonus Feature = "yahtzee-bonus"
-	Equilizer    Feature = "equilizer"
-	Ordered      Feature = "ordered"
-	Official     Feature = "official"
+	SixDice       Feature = "six-dice"
+	TheChance     Feature = "the-chance"
+	YahtzeeBonus  Feature = "yahtzee-bonus"
+	Equilizer     Feature = "equilizer"
+	Ordered       Feature = "ordered"
+	Official      Feature = "official"
+	Triple        Feature = "triple"
+	YachtRules    Feature = "yacht"
+	GeneralaRules Feature = "generala"
+	MaxiRules     Feature = "maxi"
+	SavedRolls    Feature = "saved-rolls"
+	PokerDice     Feature = "poker-dice"
+	Teams         Feature = "teams"
+	Duplicate     Feature = "duplicate"
+	Simultaneous  Feature = "simultaneous"
 )
 
-func Features() []Feature {
-	return []Feature{
-		SixDice,
-		YahtzeeBonus,
-		TheChance,
-		Equilizer,
-		Ordered,
-		Official,
-	}
-}
-
 // NewGame initializes an empty Game.
 func NewGame(features ...Feature) *Game {
-	dices := NumberOfDices
+	return NewGameWithOptions(GameOptions{}, features...)
+}
+
+// NewGameWithOptions initializes an empty Game played with the house rules in
+// `options`.
+func NewGameWithOptions(options GameOptions, features ...Feature) *Game {
 	if features == nil {
 		features = []Feature{}
 	}
-	if ContainsFeature(features, SixDice) {
-		dices = 6
+	dices := dicesFor(features...)
+	if options.Dices > 0 {
+		dices = options.Dices
 	}
+	faceNames := RulesetFor(features...).FaceNames
 	dd := make([]*Dice, dices)
 	for i := 0; i < dices; i++ {
 		dd[i] = &Dice{
 			Value: 1,
 		}
+		if faceNames != nil {
+			dd[i].Face = faceNames[0]
+		}
 	}
 
-	scorer := ComposeScorer(features...)
+	scorer := ComposeScorerWithOptions(options, features...)
 
-	return &Game{
+	g := &Game{
 		Players:  []*Player{},
 		Dices:    dd,
 		Features: features,
 		Scorer:   scorer,
 		Context:  map[string]interface{}{},
 	}
+	if options != (GameOptions{}) {
+		g.Options = &options
+	}
+
+	return g
 }
 
 func ComposeScorer(features ...Feature) *Score {
-	scorer := &Score{
-		PreScoreActions: []func(game *Game){},
-		ScoreActions:    NewDefaultScorer(),
-		PostScoreActions: []func(game *Game){
-			DefaultUpperSectionBonusAction,
-		},
-		PostGameActions: []func(game *Game){},
-	}
-
-	if ContainsFeature(features, TheChance) {
-		scorer.PostGameActions = append(scorer.PostGameActions, TheChanceAction)
-	}
+	return ComposeScorerWithOptions(GameOptions{}, features...)
+}
 
-	if ContainsFeature(features, YahtzeeBonus) {
-		scorer.PreScoreActions = append(scorer.PreScoreActions, YahtzeeBonusPreScoreAction)
-		scorer.PostScoreActions = append(scorer.PostScoreActions, YahtzeeBonusPostScoreAction)
+// ComposeScorerWithOptions returns the scorer of a game played with the
+// features and the house rules in `options`.
+func ComposeScorerWithOptions(options GameOptions, features ...Feature) *Score {
+	ruleset := RulesetFor(features...).WithFaces(options.faces())
 
-		scorer.ScoreActions[FullHouse] = YahtzeeBonusFullHouse
-		scorer.ScoreActions[SmallStraight] = YahtzeeBonusSmallStraight
-		scorer.ScoreActions[LargeStraight] = YahtzeeBonusLargeStraight
+	scorer := &Score{
+		ScoreRules:       []func(game *Game, c Category) error{},
+		PreScoreActions:  []func(game *Game){},
+		ScoreActions:     ruleset.Scorers(),
+		PostScoreActions: []func(game *Game){},
+		PostGameActions:  []func(game *Game){},
 	}
 
-	if ContainsFeature(features, Equilizer) {
-		scorer.PreScoreActions = append(scorer.PreScoreActions, EquilizerPreScoreAction)
-		scorer.PostScoreActions = append(scorer.PostScoreActions, EquilizerPostScoreAction)
+	if ruleset.UpperSectionBonus > 0 {
+		scorer.PostScoreActions = append(scorer.PostScoreActions, DefaultUpperSectionBonusAction)
 	}
 
-	if ContainsFeature(features, Official) {
-		scorer.PreScoreActions = append(scorer.PreScoreActions, OfficialYahtzeeBonusPreScoreAction)
-		scorer.PostScoreActions = append(scorer.PostScoreActions, OfficialYahtzeeBonusPostScoreAction)
-
-		scorer.ScoreActions[ThreeOfAKind] = OfficialThreeOfAKind
-		scorer.ScoreActions[FourOfAKind] = OfficialFourOfAKind
-		scorer.ScoreActions[FullHouse] = OfficialFullHouse
-		scorer.ScoreActions[SmallStraight] = OfficialSmallStraight
-		scorer.ScoreActions[LargeStraight] = OfficialLargeStraight
+	for _, f := range orderFeatures(features) {
+		d, _ := LookupFeature(f)
+		scorer.ScoreRules = append(scorer.ScoreRules, d.ScoreRules...)
+		scorer.PreScoreActions = append(scorer.PreScoreActions, d.PreScoreActions...)
+		scorer.PostScoreActions = append(scorer.PostScoreActions, d.PostScoreActions...)
+		scorer.PostGameActions = append(scorer.PostGameActions, d.PostGameActions...)
+		for c, action := range d.ScoreActions {
+			scorer.ScoreActions[c] = action
+		}
 	}
 
 	return scorer
@@ -210,3 +419,58 @@ func ContainsFeature(s []Feature, e Feature) bool {
 func (g *Game) HasFeature(f Feature) bool {
 	return ContainsFeature(g.Features, f)
 }
+
+// NumberOfColumns returns how many score sheet columns the players have.
+func (g *Game) NumberOfColumns() int {
+	if g.HasFeature(Triple) {
+		return 3
+	}
+	return 1
+}
+
+// Categories returns the categories of the game in the order of the score
+// sheet.
+func (g *Game) Categories() []Category {
+	return g.Ruleset().Categories
+}
+
+// Faces returns how many faces the dices of the game have.
+func (g *Game) Faces() int {
+	if g.Options == nil {
+		return DefaultFaces
+	}
+	return g.Options.faces()
+}
+
+// Rounds returns how many rounds the game lasts.
+func (g *Game) Rounds() int {
+	rounds := g.Ruleset().Rounds()
+	if g.Options != nil && g.Options.Rounds > 0 {
+		rounds = g.Options.Rounds
+	}
+	return rounds * g.NumberOfColumns()
+}
+
+// CurrentScoreSheet returns the score sheet the current player scores into.
+func (g *Game) CurrentScoreSheet() map[Category]int {
+	return g.PlayerSheet(g.Players[g.CurrentPlayer], g.Column)
+}
+
+// OrderedCategory returns the only category that can be scored in the
+// current round when the game is Ordered.
+func (g *Game) OrderedCategory() Category {
+	if g.Ruleset().Hands > 0 {
+		return Hand
+	}
+	return g.Categories()[g.Round/g.NumberOfColumns()]
+}
+
+// FaceName returns the symbol of the dice face with the `value`. It's empty
+// when the rules don't name the faces.
+func (g *Game) FaceName(value int) string {
+	names := g.Ruleset().FaceNames
+	if value < 1 || value > len(names) {
+		return ""
+	}
+	return names[value-1]
+}
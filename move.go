@@ -0,0 +1,73 @@
+package yahtzee
+
+// Move is a roll or a score of a player, kept for the analysis of the game.
+type Move struct {
+	// User made the move
+	User User
+
+	// Round is the round of the move
+	Round int
+
+	// RollCount is the number of rolls in the turn after the move
+	RollCount int
+
+	// Kept has the indices of the dices locked for the roll
+	Kept []int
+
+	// Dices has the values of the dices after the roll, or the scored ones
+	Dices []int
+
+	// Category is where the dices were scored; it's empty for the rolls
+	Category Category `json:",omitempty"`
+
+	// Column is the score sheet column the dices were scored in
+	Column int `json:",omitempty"`
+
+	// Sheet is the score sheet of the player before the score
+	Sheet map[Category]int `json:",omitempty"`
+}
+
+// IsScore tells if the move scored the dices.
+func (m Move) IsScore() bool {
+	return m.Category != ""
+}
+
+// RollMove returns the move of the user's last roll.
+func (g *Game) RollMove(u User) Move {
+	p := g.Player(u)
+	res := Move{
+		User:      u,
+		Round:     g.Round,
+		RollCount: g.RollCountOf(p),
+		Kept:      []int{},
+		Dices:     []int{},
+	}
+	for i, d := range g.DicesOf(p) {
+		if d.Locked {
+			res.Kept = append(res.Kept, i)
+		}
+		res.Dices = append(res.Dices, d.Value)
+	}
+	return res
+}
+
+// ScoreMove returns the move of the user scoring the dices in the category of
+// the column. It has to be called before the score.
+func (g *Game) ScoreMove(u User, column int, c Category) Move {
+	p := g.Player(u)
+	res := Move{
+		User:      u,
+		Round:     g.Round,
+		RollCount: g.RollCountOf(p),
+		Dices:     []int{},
+		Category:  c,
+		Column:    column,
+	}
+	for _, d := range g.DicesOf(p) {
+		res.Dices = append(res.Dices, d.Value)
+	}
+	if p != nil && column >= 0 && column < g.NumberOfColumns() {
+		res.Sheet = copySheet(g.PlayerSheet(p, column))
+	}
+	return res
+}
@@ -0,0 +1,227 @@
+package yahtzee
+
+import "github.com/akarasz/yahtzee/internal/dice"
+
+// Odds has the chances of a category when the player plays the remaining rolls
+// of the turn for it.
+type Odds struct {
+	// Probability is the chance of scoring points in the category
+	Probability float64
+
+	// Expected is the expected points of the category
+	Expected float64
+
+	// Distribution has the probability of each number of points
+	Distribution map[int]float64
+}
+
+// Odds returns the odds of every open category of the current player. The
+// locked dices are kept for the next roll, and the later rolls are played for
+// the best chance of scoring points in the category, then for the most
+// points. Every dice is rolled when the player hasn't rolled yet. The player
+// can score after any later roll, where the dices are worth what the rules give
+// for them at that roll.
+func (g *Game) Odds() (map[Category]*Odds, error) {
+	if len(g.Players) == 0 {
+		return nil, ErrNoPlayers
+	}
+	if g.IsOver() {
+		return nil, ErrGameOver
+	}
+
+	n, faces := len(g.Dices), g.Faces()
+	if dice.Count(n, faces, MaxCombinations) > MaxCombinations {
+		return nil, ErrTooManyCombinations
+	}
+
+	e := newExpecter(g, n, faces)
+
+	rolls := g.RollsLeft()
+
+	// points has the points of the combinations by the rolls left after
+	// them
+	points := [][][]int{e.pointsAt(e.final)}
+	for k := 1; k < rolls; k++ {
+		points = append(points, e.pointsAt(e.final-k))
+	}
+	current := e.Index[e.Key(countsOf(g.Dices, faces))]
+	locked := make(dice.Counts, faces)
+	if g.RollCount > 0 {
+		for _, d := range g.Dices {
+			if d.Locked {
+				locked[d.Value-1]++
+			}
+		}
+	}
+	hold, ok := e.HoldIndex[e.Key(locked)]
+	if !ok {
+		hold = e.EmptyHold
+	}
+
+	res := map[Category]*Odds{}
+	for t, c := range e.targets {
+		o := newOddsOf(points, t)
+
+		var dist []float64
+		if rolls == 0 {
+			dist = o.levels[current]
+		} else {
+			for k := 1; k < rolls; k++ {
+				o.roll(e, points[k])
+			}
+			dist = o.holdDistributions(e)[hold]
+		}
+
+		res[c] = o.odds(dist)
+	}
+
+	return res, nil
+}
+
+// oddsOf calculates the point distributions of a target over the combinations
+// of the dices.
+type oddsOf struct {
+	target int
+
+	// values has the different points of the target, and index has the index
+	// of each
+	values []int
+	index  map[int]int
+
+	// levels has the distribution of each combination over the values with
+	// the rolls left so far
+	levels [][]float64
+}
+
+// newOddsOf starts the distributions of the target from the points of the
+// combinations after the last roll. The points after the earlier rolls are
+// needed for the values they can score.
+func newOddsOf(points [][][]int, t int) *oddsOf {
+	o := &oddsOf{
+		target: t,
+		index:  map[int]int{},
+	}
+
+	for _, level := range points {
+		for _, p := range level {
+			if _, ok := o.index[p[t]]; !ok {
+				o.index[p[t]] = len(o.values)
+				o.values = append(o.values, p[t])
+			}
+		}
+	}
+
+	o.levels = o.scored(points[0])
+	return o
+}
+
+// scored returns the distributions of scoring the combinations for their
+// points.
+func (o *oddsOf) scored(points [][]int) [][]float64 {
+	res := make([][]float64, len(points))
+	for s, p := range points {
+		res[s] = make([]float64, len(o.values))
+		res[s][o.index[p[o.target]]] = 1
+	}
+	return res
+}
+
+// roll moves the levels one roll earlier: every combination is scored for the
+// `points` or keeps its best hold for the next roll.
+func (o *oddsOf) roll(e *expecter, points [][]int) {
+	holds := o.holdDistributions(e)
+	scored := o.scored(points)
+	next := make([][]float64, len(e.States))
+	for s := range e.States {
+		best := scored[s]
+		for _, h := range e.SubHolds[s] {
+			if o.better(holds[h], best) {
+				best = holds[h]
+			}
+		}
+		next[s] = best
+	}
+	o.levels = next
+}
+
+// holdDistributions returns the distribution of rolling the dices beside each
+// hold.
+func (o *oddsOf) holdDistributions(e *expecter) [][]float64 {
+	res := make([][]float64, len(e.Holds))
+	for h := range e.Holds {
+		res[h] = make([]float64, len(o.values))
+		for _, out := range e.Outcomes[h] {
+			for v, p := range o.levels[out.State] {
+				res[h][v] += out.Probability * p
+			}
+		}
+	}
+	return res
+}
+
+// better tells if the distribution `a` has a better chance to score points
+// than `b`, or the same chance with more expected points.
+func (o *oddsOf) better(a, b []float64) bool {
+	const epsilon = 1e-12
+
+	pa, ea := o.chances(a)
+	pb, eb := o.chances(b)
+	if pa > pb+epsilon {
+		return true
+	}
+	return pa > pb-epsilon && ea > eb+epsilon
+}
+
+// chances returns the probability of scoring points and the expected points
+// of the distribution.
+func (o *oddsOf) chances(dist []float64) (float64, float64) {
+	var probability, expected float64
+	for v, p := range dist {
+		if o.values[v] > 0 {
+			probability += p
+		}
+		expected += p * float64(o.values[v])
+	}
+	return probability, expected
+}
+
+func (o *oddsOf) odds(dist []float64) *Odds {
+	res := &Odds{
+		Distribution: map[int]float64{},
+	}
+	res.Probability, res.Expected = o.chances(dist)
+	for v, p := range dist {
+		if p > 0 {
+			res.Distribution[o.values[v]] = p
+		}
+	}
+	return res
+}
+
+// pointsAt returns the points of every combination in each target after the
+// `rollCount`th roll of the turn. The categories the rules don't allow for the
+// dices are worth nothing.
+func (e *expecter) pointsAt(rollCount int) [][]int {
+	g := e.game
+	dices, current := g.Dices, g.RollCount
+	defer func() { g.Dices, g.RollCount = dices, current }()
+	g.RollCount = rollCount
+
+	res := make([][]int, len(e.States))
+	for i, s := range e.States {
+		g.Dices = []*Dice{}
+		for v, c := range s {
+			for j := 0; j < c; j++ {
+				g.Dices = append(g.Dices, &Dice{Value: v + 1})
+			}
+		}
+
+		res[i] = make([]int, len(e.targets))
+		for t, c := range e.targets {
+			if g.CheckScoreRules(c) == nil {
+				res[i][t] = g.Scorer.ScoreActions[c](g)
+			}
+		}
+	}
+	return res
+}
@@ -0,0 +1,101 @@
+package yahtzee
+
+import "errors"
+
+const (
+	// DefaultNumberOfDices shows how many dices are used for a game by default.
+	DefaultNumberOfDices = 5
+
+	// DefaultRollsPerTurn shows how many times a player can roll in a turn by
+	// default.
+	DefaultRollsPerTurn = 3
+
+	// MaxNumberOfDices is the most dices a game can be played with.
+	MaxNumberOfDices = 10
+
+	// MaxRollsPerTurn is the most rolls a turn can have.
+	MaxRollsPerTurn = 10
+
+	// DefaultFaces shows how many faces the dices have by default.
+	DefaultFaces = 6
+
+	// MinFaces is the least faces a dice can have.
+	MinFaces = 4
+
+	// MaxFaces is the most faces a dice can have.
+	MaxFaces = 12
+)
+
+var (
+	// ErrInvalidOptions is returned when the game can't be played with the
+	// options.
+	ErrInvalidOptions = errors.New("invalid options")
+)
+
+// GameOptions has the house rules of a game. The zero values mean the defaults
+// of the game's ruleset.
+type GameOptions struct {
+	// Rounds limits how many rounds are played in a score sheet column
+	Rounds int `json:",omitempty"`
+
+	// RollsPerTurn shows how many times a player can roll in a turn
+	RollsPerTurn int `json:",omitempty"`
+
+	// Dices shows how many dices are used for the game
+	Dices int `json:",omitempty"`
+
+	// Faces shows how many faces the dices have (d4, d6, d8, d10 or d12). The
+	// dices need at least the faces of the longest straight of the ruleset.
+	Faces int `json:",omitempty"`
+}
+
+// Validate checks if a game with the features can be played with the options.
+func (o GameOptions) Validate(features ...Feature) error {
+	if o.Faces != 0 && (o.Faces < MinFaces || o.Faces > MaxFaces || o.Faces%2 != 0) {
+		return ErrInvalidOptions
+	}
+	ruleset := RulesetFor(features...).WithFaces(o.faces())
+	if ruleset.FaceNames != nil && o.Faces != 0 && o.Faces != len(ruleset.FaceNames) {
+		return ErrInvalidOptions
+	}
+	if o.Faces != 0 && o.Faces < ruleset.MinFaces {
+		return ErrInvalidOptions
+	}
+	if ruleset.Hands > 0 && o.Dices != 0 && o.Dices != ruleset.Dices {
+		return ErrInvalidOptions
+	}
+	if o.Rounds < 0 || o.Rounds > ruleset.Rounds() {
+		return ErrInvalidOptions
+	}
+	if o.RollsPerTurn < 0 || o.RollsPerTurn > MaxRollsPerTurn {
+		return ErrInvalidOptions
+	}
+	if o.Dices != 0 && (o.Dices < dicesFor(features...) || o.Dices > MaxNumberOfDices) {
+		return ErrInvalidOptions
+	}
+	return nil
+}
+
+// dicesFor returns how many dices a game with the features is played with by
+// default.
+func dicesFor(features ...Feature) int {
+	if ContainsFeature(features, SixDice) {
+		return 6
+	}
+	return RulesetFor(features...).Dices
+}
+
+func (o GameOptions) faces() int {
+	if o.Faces > 0 {
+		return o.Faces
+	}
+	return DefaultFaces
+}
+
+// RollsPerTurn returns how many times a player can roll in a turn.
+func (g *Game) RollsPerTurn() int {
+	if g.Options != nil && g.Options.RollsPerTurn > 0 {
+		return g.Options.RollsPerTurn
+	}
+	return DefaultRollsPerTurn
+}
@@ -0,0 +1,64 @@
+package yahtzee
+
+import "math/rand"
+
+// Roller produces the values of the rolled dices.
+type Roller interface {
+	// Roll returns the value of a dice between 1 and `faces`.
+	Roll(faces int) int
+}
+
+// NewRoller returns a Roller that generates the dice values from `seed`. The
+// sequence of the values depends only on the seed, so the roller continues
+// from the `count`th value.
+func NewRoller(seed int64, count int) Roller {
+	return &seededRoller{
+		seed:  seed,
+		count: count,
+	}
+}
+
+type seededRoller struct {
+	seed  int64
+	count int
+}
+
+func (r *seededRoller) Roll(faces int) int {
+	r.count++
+	return int(mix(uint64(r.seed)+uint64(r.count)*0x9e3779b97f4a7c15)%uint64(faces)) + 1
+}
+
+// mix is the finalizer of the splitmix64 generator.
+func mix(z uint64) uint64 {
+	z = (z ^ (z >> 30)) * 0xbf58476d1ce4e5b9
+	z = (z ^ (z >> 27)) * 0x94d049bb133111eb
+	return z ^ (z >> 31)
+}
+
+// DuplicateRoll returns the value of the dice on `index` in the `roll`th roll
+// of the `round` between 1 and `faces`. The value depends only on the seed and
+// the position, so every player of a round gets the same dices for the same
+// roll, whichever dices they kept.
+func DuplicateRoll(seed int64, round, roll, index, faces int) int {
+	z := mix(uint64(seed) + uint64(round+1)*0x9e3779b97f4a7c15)
+	z = mix(z + uint64(roll+1)*0x9e3779b97f4a7c15)
+	z = mix(z + uint64(index+1)*0x9e3779b97f4a7c15)
+	return int(z%uint64(faces)) + 1
+}
+
+// roller returns the Roller of the game, creating one from the recorded seed
+// when it's missing.
+func (g *Game) roller() Roller {
+	if g.Roller == nil {
+		g.Roller = NewRoller(g.seed(), g.RollCounter)
+	}
+	return g.Roller
+}
+
+// seed returns the seed of the game, choosing one when it's not set yet.
+func (g *Game) seed() int64 {
+	if g.Seed == 0 {
+		g.Seed = rand.Int63()
+	}
+	return g.Seed
+}
@@ -0,0 +1,159 @@
+package yahtzee
+
+// Ruleset describes the score sheet a game is played with.
+type Ruleset struct {
+	// Categories has the categories in the order of the score sheet
+	Categories []Category
+
+	// Dices shows how many dices are used for the game
+	Dices int
+
+	// Faces shows how many faces the dices have. The upper section has a
+	// category for every face.
+	Faces int
+
+	// MinFaces is the least faces the dices can have, as the straights need
+	// that many faces in a row
+	MinFaces int
+
+	// FaceNames has the symbols of the dice faces from the lowest value when
+	// the faces are not pips. The faces can't be changed then.
+	FaceNames []string
+
+	// Hands shows how many rounds are played when the rounds are decided by
+	// comparing hands instead of filling a score sheet.
+	Hands int
+
+	// UpperSectionThreshold is the total of the upper section needed for the
+	// bonus
+	UpperSectionThreshold int
+
+	// UpperSectionBonus is given when the upper section reaches the threshold.
+	// There is no bonus when it's zero.
+	UpperSectionBonus int
+
+	// Scorers returns the scorers of the categories
+	Scorers func() Scorers
+
+	// UpperScorer returns the scorer of the upper section category counting
+	// the dices with the `value`
+	UpperScorer func(value int) func(game *Game) int
+}
+
+// Rounds returns how many rounds are needed to fill a score sheet column.
+func (r *Ruleset) Rounds() int {
+	if r.Hands > 0 {
+		return r.Hands
+	}
+	return len(r.Categories)
+}
+
+// RulesetFor returns the ruleset the game with the features is played with.
+func RulesetFor(features ...Feature) *Ruleset {
+	for _, f := range orderFeatures(features) {
+		if d, _ := LookupFeature(f); d.Ruleset != nil {
+			return d.Ruleset()
+		}
+	}
+	return DefaultRuleset()
+}
+
+// DefaultRuleset returns the rules of Yahtzee.
+func DefaultRuleset() *Ruleset {
+	return &Ruleset{
+		Categories:            Categories(),
+		Dices:                 DefaultNumberOfDices,
+		Faces:                 DefaultFaces,
+		MinFaces:              5,
+		UpperSectionThreshold: 63,
+		UpperSectionBonus:     35,
+		Scorers:               NewDefaultScorer,
+		UpperScorer:           DefaultUpper,
+	}
+}
+
+// YachtRuleset returns the rules of the classic Yacht.
+func YachtRuleset() *Ruleset {
+	return &Ruleset{
+		Categories:  YachtCategories(),
+		Dices:       DefaultNumberOfDices,
+		Faces:       DefaultFaces,
+		MinFaces:    5,
+		Scorers:     NewYachtScorer,
+		UpperScorer: DefaultUpper,
+	}
+}
+
+// GeneralaRuleset returns the rules of Generala.
+func GeneralaRuleset() *Ruleset {
+	return &Ruleset{
+		Categories:  GeneralaCategories(),
+		Dices:       DefaultNumberOfDices,
+		Faces:       DefaultFaces,
+		MinFaces:    5,
+		Scorers:     NewGeneralaScorer,
+		UpperScorer: DefaultUpper,
+	}
+}
+
+// MaxiRuleset returns the rules of Maxi Yahtzee.
+func MaxiRuleset() *Ruleset {
+	return &Ruleset{
+		Categories:            MaxiCategories(),
+		Dices:                 6,
+		Faces:                 DefaultFaces,
+		MinFaces:              6,
+		UpperSectionThreshold: 84,
+		UpperSectionBonus:     50,
+		Scorers:               NewMaxiScorer,
+		UpperScorer:           MaxiUpper,
+	}
+}
+
+// PokerDiceRuleset returns the rules of Poker Dice.
+func PokerDiceRuleset() *Ruleset {
+	return &Ruleset{
+		Categories:  PokerDiceCategories(),
+		Dices:       DefaultNumberOfDices,
+		Faces:       DefaultFaces,
+		FaceNames:   []string{"9", "10", "J", "Q", "K", "A"},
+		Hands:       5,
+		Scorers:     NewPokerDiceScorer,
+		UpperScorer: DefaultUpper,
+	}
+}
+
+// WithFaces returns the ruleset played with dices having `faces` faces. The
+// upper section gets a category for every face and its bonus threshold
+// changes in proportion with the sum of the faces.
+func (r *Ruleset) WithFaces(faces int) *Ruleset {
+	if faces == r.Faces || r.FaceNames != nil {
+		return r
+	}
+
+	res := *r
+	res.Faces = faces
+	res.Categories = append(UpperCategories(faces), r.Categories[r.Faces:]...)
+	res.UpperSectionThreshold = r.UpperSectionThreshold * faceSum(faces) / faceSum(r.Faces)
+	res.Scorers = func() Scorers {
+		scorers := r.Scorers()
+		for _, c := range UpperCategories(r.Faces) {
+			delete(scorers, c)
+		}
+		for i, c := range UpperCategories(faces) {
+			scorers[c] = r.UpperScorer(i + 1)
+		}
+		return scorers
+	}
+	return &res
+}
+
+// Ruleset returns the ruleset of the game.
+func (g *Game) Ruleset() *Ruleset {
+	return RulesetFor(g.Features...).WithFaces(g.Faces())
+}
+
+// faceSum returns the sum of the faces of a dice.
+func faceSum(faces int) int {
+	return faces * (faces + 1) / 2
+}
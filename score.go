@@ -1,6 +1,9 @@
 package yahtzee
 
+import "sort"
+
 type Score struct {
+	ScoreRules       []func(game *Game, c Category) error
 	PreScoreActions  []func(game *Game)
 	ScoreActions     Scorers
 	PostScoreActions []func(game *Game)
@@ -57,6 +60,14 @@ func DefaultSixes(game *Game) int {
 	return min(countDice(6, game.Dices)*6, 5*6)
 }
 
+// DefaultUpper returns the scorer of the upper section category counting the
+// dices with the `value`.
+func DefaultUpper(value int) func(game *Game) int {
+	return func(game *Game) int {
+		return min(countDice(value, game.Dices)*value, 5*value)
+	}
+}
+
 func DefaultThreeOfAKind(game *Game) int {
 	occurrences := map[int]int{}
 	for _, d := range game.Dices {
@@ -79,7 +90,7 @@ func DefaultFourOfAKind(game *Game) int {
 	s := 0
 	for k, v := range occurrences {
 		if v >= 4 {
-			s = 4 * k
+			s = max(s, 4*k)
 		}
 	}
 	return s
@@ -112,14 +123,7 @@ func DefaultFullHouse(game *Game) int {
 
 func DefaultSmallStraight(game *Game) int {
 	s := 0
-	hit := [6]bool{}
-	for _, d := range game.Dices {
-		hit[d.Value-1] = true
-	}
-
-	if (hit[0] && hit[1] && hit[2] && hit[3]) ||
-		(hit[1] && hit[2] && hit[3] && hit[4]) ||
-		(hit[2] && hit[3] && hit[4] && hit[5]) {
+	if longestStraight(game.Dices) >= 4 {
 		s = 30
 	}
 	return s
@@ -127,13 +131,7 @@ func DefaultSmallStraight(game *Game) int {
 
 func DefaultLargeStraight(game *Game) int {
 	s := 0
-	hit := [6]bool{}
-	for _, d := range game.Dices {
-		hit[d.Value-1] = true
-	}
-
-	if (hit[0] && hit[1] && hit[2] && hit[3] && hit[4]) ||
-		(hit[1] && hit[2] && hit[3] && hit[4] && hit[5]) {
+	if longestStraight(game.Dices) >= 5 {
 		s = 40
 	}
 	return s
@@ -146,36 +144,38 @@ func DefaultYahtzee(game *Game) int {
 	return 0
 }
 
+// DefaultChance sums the best five dices.
 func DefaultChance(game *Game) int {
+	values := make([]int, len(game.Dices))
+	for i, d := range game.Dices {
+		values[i] = d.Value
+	}
+	sort.Sort(sort.Reverse(sort.IntSlice(values)))
+
 	s := 0
-	for i := 0; i < len(game.Dices); i++ {
-		sum := 0
-		for j, d := range game.Dices {
-			if len(game.Dices) > 5 && j == i {
-				continue
-			}
-			sum += d.Value
-		}
-		s = max(s, sum)
+	for i := 0; i < len(values) && i < 5; i++ {
+		s += values[i]
 	}
 	return s
 }
 
 func DefaultUpperSectionBonusAction(game *Game) {
-	if _, ok := game.Players[game.CurrentPlayer].ScoreSheet[Bonus]; !ok {
+	sheet := game.CurrentScoreSheet()
+	if _, ok := sheet[Bonus]; !ok {
+		ruleset := game.Ruleset()
+
 		var total, types int
-		for k, v := range game.Players[game.CurrentPlayer].ScoreSheet {
-			if k == Ones || k == Twos || k == Threes ||
-				k == Fours || k == Fives || k == Sixes {
+		for _, c := range UpperCategories(ruleset.Faces) {
+			if v, ok := sheet[c]; ok {
 				types++
 				total += v
 			}
 		}
 
-		if total >= 63 {
-			game.Players[game.CurrentPlayer].ScoreSheet[Bonus] = 35
-		} else if types == 6 {
-			game.Players[game.CurrentPlayer].ScoreSheet[Bonus] = 0
+		if total >= ruleset.UpperSectionThreshold {
+			sheet[Bonus] = ruleset.UpperSectionBonus
+		} else if types == ruleset.Faces {
+			sheet[Bonus] = 0
 		}
 	}
 }
@@ -183,35 +183,35 @@ func DefaultUpperSectionBonusAction(game *Game) {
 //Yahtzee-bonus
 
 func YahtzeeBonusFullHouse(game *Game) int {
-	if _, yahtzeeScored := game.Players[game.CurrentPlayer].ScoreSheet[Yahtzee]; yahtzeeScored && isYahtzee(game.Dices) {
+	if _, yahtzeeScored := game.CurrentScoreSheet()[Yahtzee]; yahtzeeScored && isYahtzee(game.Dices) {
 		return 25
 	}
 	return DefaultFullHouse(game)
 }
 
 func YahtzeeBonusSmallStraight(game *Game) int {
-	if _, yahtzeeScored := game.Players[game.CurrentPlayer].ScoreSheet[Yahtzee]; yahtzeeScored && isYahtzee(game.Dices) {
+	if _, yahtzeeScored := game.CurrentScoreSheet()[Yahtzee]; yahtzeeScored && isYahtzee(game.Dices) {
 		return 30
 	}
 	return DefaultSmallStraight(game)
 }
 
 func YahtzeeBonusLargeStraight(game *Game) int {
-	if _, yahtzeeScored := game.Players[game.CurrentPlayer].ScoreSheet[Yahtzee]; yahtzeeScored && isYahtzee(game.Dices) {
+	if _, yahtzeeScored := game.CurrentScoreSheet()[Yahtzee]; yahtzeeScored && isYahtzee(game.Dices) {
 		return 40
 	}
 	return DefaultLargeStraight(game)
 }
 
 func YahtzeeBonusPreScoreAction(g *Game) {
-	yahtzeeValue, yahtzeeScored := g.Players[g.CurrentPlayer].ScoreSheet[Yahtzee]
+	yahtzeeValue, yahtzeeScored := g.CurrentScoreSheet()[Yahtzee]
 	yahtzee := isYahtzee(g.Dices)
 	g.Context["yahtzeeBonusEligible"] = yahtzeeScored && yahtzee && yahtzeeValue != 0
 }
 
 func YahtzeeBonusPostScoreAction(g *Game) {
 	if val, ok := g.Context["yahtzeeBonusEligible"]; ok && val.(bool) {
-		g.Players[g.CurrentPlayer].ScoreSheet[Yahtzee] += 100
+		g.CurrentScoreSheet()[Yahtzee] += 100
 	}
 	delete(g.Context, "yahtzeeBonusEligible")
 }
@@ -219,12 +219,13 @@ func YahtzeeBonusPostScoreAction(g *Game) {
 // The Chance
 
 func TheChanceAction(g *Game) {
-	for _, p := range g.Players {
-		s := 0
-		for _, v := range p.ScoreSheet {
-			s += v
+	for _, t := range g.Teams {
+		if t.Total() == 5 {
+			t.ScoreSheet[ChanceBonus] = 495
 		}
-		if s == 5 {
+	}
+	for _, p := range g.Players {
+		if p.Team == "" && p.Total() == 5 {
 			p.ScoreSheet[ChanceBonus] = 495
 		}
 	}
@@ -234,8 +235,8 @@ func TheChanceAction(g *Game) {
 
 func EquilizerPreScoreAction(g *Game) {
 	notScoredCategories := []Category{}
-	for _, c := range Categories() {
-		if _, ok := g.Players[g.CurrentPlayer].ScoreSheet[c]; !ok {
+	for _, c := range g.Categories() {
+		if _, ok := g.CurrentScoreSheet()[c]; !ok {
 			notScoredCategories = append(notScoredCategories, c)
 		}
 	}
@@ -248,13 +249,13 @@ func EquilizerPostScoreAction(g *Game) {
 
 	if val, ok := g.Context["equilizerNotScoredCategories"]; ok {
 		for _, c := range val.([]Category) {
-			if s, ok := g.Players[g.CurrentPlayer].ScoreSheet[c]; ok {
+			if s, ok := g.CurrentScoreSheet()[c]; ok {
 				if s > 0 {
 					return
 				}
 				for _, p := range g.Players {
-					if _, ok := p.ScoreSheet[c]; ok {
-						p.ScoreSheet[c] = 0
+					if _, ok := g.PlayerSheet(p, g.Column)[c]; ok {
+						g.PlayerSheet(p, g.Column)[c] = 0
 					}
 				}
 				return
@@ -292,13 +293,13 @@ func OfficialFourOfAKind(game *Game) int {
 }
 
 func OfficialFullHouse(game *Game) int {
-	if _, yahtzeeScored := game.Players[game.CurrentPlayer].ScoreSheet[Yahtzee]; yahtzeeScored && isYahtzee(game.Dices) {
+	if _, yahtzeeScored := game.CurrentScoreSheet()[Yahtzee]; yahtzeeScored && isYahtzee(game.Dices) {
 		occurrences := map[int]int{}
 		for _, d := range game.Dices {
 			occurrences[d.Value]++
 		}
 		for v, c := range occurrences {
-			if _, scored := game.Players[game.CurrentPlayer].ScoreSheet[Categories()[v-1]]; scored && c >= 5 {
+			if _, scored := game.CurrentScoreSheet()[UpperCategory(v)]; scored && c >= 5 {
 				return 25
 			}
 		}
@@ -307,13 +308,13 @@ func OfficialFullHouse(game *Game) int {
 }
 
 func OfficialSmallStraight(game *Game) int {
-	if _, yahtzeeScored := game.Players[game.CurrentPlayer].ScoreSheet[Yahtzee]; yahtzeeScored && isYahtzee(game.Dices) {
+	if _, yahtzeeScored := game.CurrentScoreSheet()[Yahtzee]; yahtzeeScored && isYahtzee(game.Dices) {
 		occurrences := map[int]int{}
 		for _, d := range game.Dices {
 			occurrences[d.Value]++
 		}
 		for v, c := range occurrences {
-			if _, scored := game.Players[game.CurrentPlayer].ScoreSheet[Categories()[v-1]]; scored && c >= 5 {
+			if _, scored := game.CurrentScoreSheet()[UpperCategory(v)]; scored && c >= 5 {
 				return 30
 			}
 		}
@@ -322,13 +323,13 @@ func OfficialSmallStraight(game *Game) int {
 }
 
 func OfficialLargeStraight(game *Game) int {
-	if _, yahtzeeScored := game.Players[game.CurrentPlayer].ScoreSheet[Yahtzee]; yahtzeeScored && isYahtzee(game.Dices) {
+	if _, yahtzeeScored := game.CurrentScoreSheet()[Yahtzee]; yahtzeeScored && isYahtzee(game.Dices) {
 		occurrences := map[int]int{}
 		for _, d := range game.Dices {
 			occurrences[d.Value]++
 		}
 		for v, c := range occurrences {
-			if _, scored := game.Players[game.CurrentPlayer].ScoreSheet[Categories()[v-1]]; scored && c >= 5 {
+			if _, scored := game.CurrentScoreSheet()[UpperCategory(v)]; scored && c >= 5 {
 				return 40
 			}
 		}
@@ -336,6 +337,58 @@ func OfficialLargeStraight(game *Game) int {
 	return DefaultLargeStraight(game)
 }
 
+// OfficialJokerRule forces the placement of an extra yahtzee: it goes to the
+// upper section box of its value when that's open, to any open lower section
+// box otherwise, and to an open upper section box only when the lower section
+// is full.
+func OfficialJokerRule(game *Game, c Category) error {
+	sheet := game.CurrentScoreSheet()
+	if _, yahtzeeScored := sheet[Yahtzee]; !yahtzeeScored || !isYahtzee(game.Dices) {
+		return nil
+	}
+
+	for _, legal := range officialJokerCategories(game) {
+		if legal == c {
+			return nil
+		}
+	}
+	return ErrJokerRules
+}
+
+// officialJokerCategories returns the boxes an extra yahtzee can be placed in.
+func officialJokerCategories(game *Game) []Category {
+	sheet := game.CurrentScoreSheet()
+	upper := UpperCategories(game.Faces())
+
+	value := 0
+	for v, c := range occurrences(game.Dices) {
+		if c >= 5 {
+			value = v
+		}
+	}
+	if _, scored := sheet[UpperCategory(value)]; !scored {
+		return []Category{UpperCategory(value)}
+	}
+
+	lower := []Category{}
+	for _, c := range game.Categories() {
+		if _, scored := sheet[c]; !scored && !containsCategory(upper, c) {
+			lower = append(lower, c)
+		}
+	}
+	if len(lower) > 0 {
+		return lower
+	}
+
+	res := []Category{}
+	for _, c := range upper {
+		if _, scored := sheet[c]; !scored {
+			res = append(res, c)
+		}
+	}
+	return res
+}
+
 func OfficialYahtzeeBonusPreScoreAction(game *Game) {
 	YahtzeeBonusPreScoreAction(game)
 }
@@ -344,6 +397,382 @@ func OfficialYahtzeeBonusPostScoreAction(game *Game) {
 	YahtzeeBonusPostScoreAction(game)
 }
 
+// Yacht
+
+var yachtScorer = Scorers{
+	Ones:           DefaultOnes,
+	Twos:           DefaultTwos,
+	Threes:         DefaultThrees,
+	Fours:          DefaultFours,
+	Fives:          DefaultFives,
+	Sixes:          DefaultSixes,
+	FullHouse:      YachtFullHouse,
+	FourOfAKind:    DefaultFourOfAKind,
+	LittleStraight: YachtLittleStraight,
+	BigStraight:    YachtBigStraight,
+	Choice:         DefaultChance,
+	Yacht:          YachtYacht,
+}
+
+func NewYachtScorer() Scorers {
+	scorer := Scorers{}
+	for key, value := range yachtScorer {
+		scorer[key] = value
+	}
+	return scorer
+}
+
+func YachtFullHouse(game *Game) int {
+	occurrences := map[int]int{}
+	for _, d := range game.Dices {
+		occurrences[d.Value]++
+	}
+	three, two := 0, 0
+	for v := game.Faces(); v >= 1; v-- {
+		if three == 0 && occurrences[v] >= 3 {
+			three = v
+		}
+	}
+	for v := game.Faces(); v >= 1; v-- {
+		if two == 0 && v != three && occurrences[v] >= 2 {
+			two = v
+		}
+	}
+
+	if three == 0 || two == 0 {
+		return 0
+	}
+	return 3*three + 2*two
+}
+
+// YachtLittleStraight scores the five lowest faces.
+func YachtLittleStraight(game *Game) int {
+	if hasStraight(game.Dices, 1, 5) {
+		return 30
+	}
+	return 0
+}
+
+// YachtBigStraight scores the five highest faces.
+func YachtBigStraight(game *Game) int {
+	if hasStraight(game.Dices, game.Faces()-4, 5) {
+		return 30
+	}
+	return 0
+}
+
+func YachtYacht(game *Game) int {
+	if isYahtzee(game.Dices) {
+		return 50
+	}
+	return 0
+}
+
+// Generala
+
+var generalaScorer = Scorers{
+	Ones:     DefaultOnes,
+	Twos:     DefaultTwos,
+	Threes:   DefaultThrees,
+	Fours:    DefaultFours,
+	Fives:    DefaultFives,
+	Sixes:    DefaultSixes,
+	Escalera: GeneralaEscalera,
+	Full:     GeneralaFull,
+	Poker:    GeneralaPoker,
+	Generala: GeneralaGenerala,
+}
+
+func NewGeneralaScorer() Scorers {
+	scorer := Scorers{}
+	for key, value := range generalaScorer {
+		scorer[key] = value
+	}
+	return scorer
+}
+
+// isServed tells if the dices were rolled only once in the turn.
+func isServed(game *Game) bool {
+	return game.RollCount == 1
+}
+
+// GeneralaEscalera scores five faces in a row. The ace can follow the highest
+// face too.
+func GeneralaEscalera(game *Game) int {
+	top := game.Faces() - 3
+	if longestStraight(game.Dices) < 5 &&
+		!(hasStraight(game.Dices, top, 4) && countDice(1, game.Dices) > 0) {
+		return 0
+	}
+	if isServed(game) {
+		return 25
+	}
+	return 20
+}
+
+func GeneralaFull(game *Game) int {
+	if DefaultFullHouse(game) == 0 {
+		return 0
+	}
+	if isServed(game) {
+		return 35
+	}
+	return 30
+}
+
+func GeneralaPoker(game *Game) int {
+	if DefaultFourOfAKind(game) == 0 {
+		return 0
+	}
+	if isServed(game) {
+		return 45
+	}
+	return 40
+}
+
+func GeneralaGenerala(game *Game) int {
+	if isYahtzee(game.Dices) {
+		return 50
+	}
+	return 0
+}
+
+// GeneralaServedAction ends the game when the current player rolled a
+// Generala with the first roll.
+func GeneralaServedAction(g *Game) {
+	if isServed(g) && isYahtzee(g.Dices) {
+		g.Winner = g.Players[g.CurrentPlayer].User
+	}
+}
+
+// Maxi
+
+var maxiScorer = Scorers{
+	Ones:          MaxiOnes,
+	Twos:          MaxiTwos,
+	Threes:        MaxiThrees,
+	Fours:         MaxiFours,
+	Fives:         MaxiFives,
+	Sixes:         MaxiSixes,
+	OnePair:       MaxiOnePair,
+	TwoPairs:      MaxiTwoPairs,
+	ThreePairs:    MaxiThreePairs,
+	ThreeOfAKind:  DefaultThreeOfAKind,
+	FourOfAKind:   DefaultFourOfAKind,
+	FiveOfAKind:   MaxiFiveOfAKind,
+	SmallStraight: MaxiSmallStraight,
+	LargeStraight: MaxiLargeStraight,
+	FullStraight:  MaxiFullStraight,
+	FullHouse:     YachtFullHouse,
+	Castle:        MaxiCastle,
+	Tower:         MaxiTower,
+	Chance:        MaxiChance,
+	MaxiYahtzee:   MaxiMaxiYahtzee,
+}
+
+func NewMaxiScorer() Scorers {
+	scorer := Scorers{}
+	for key, value := range maxiScorer {
+		scorer[key] = value
+	}
+	return scorer
+}
+
+// MaxiUpper returns the scorer of the upper section category counting the
+// dices with the `value`.
+func MaxiUpper(value int) func(game *Game) int {
+	return func(game *Game) int {
+		return countDice(value, game.Dices) * value
+	}
+}
+
+func MaxiOnes(game *Game) int {
+	return countDice(1, game.Dices)
+}
+
+func MaxiTwos(game *Game) int {
+	return countDice(2, game.Dices) * 2
+}
+
+func MaxiThrees(game *Game) int {
+	return countDice(3, game.Dices) * 3
+}
+
+func MaxiFours(game *Game) int {
+	return countDice(4, game.Dices) * 4
+}
+
+func MaxiFives(game *Game) int {
+	return countDice(5, game.Dices) * 5
+}
+
+func MaxiSixes(game *Game) int {
+	return countDice(6, game.Dices) * 6
+}
+
+func MaxiOnePair(game *Game) int {
+	pairs := pairValues(game.Dices, game.Faces())
+	if len(pairs) < 1 {
+		return 0
+	}
+	return 2 * pairs[0]
+}
+
+func MaxiTwoPairs(game *Game) int {
+	pairs := pairValues(game.Dices, game.Faces())
+	if len(pairs) < 2 {
+		return 0
+	}
+	return 2*pairs[0] + 2*pairs[1]
+}
+
+func MaxiThreePairs(game *Game) int {
+	pairs := pairValues(game.Dices, game.Faces())
+	if len(pairs) < 3 {
+		return 0
+	}
+	return 2*pairs[0] + 2*pairs[1] + 2*pairs[2]
+}
+
+func MaxiFiveOfAKind(game *Game) int {
+	for v := game.Faces(); v >= 1; v-- {
+		if countDice(v, game.Dices) >= 5 {
+			return 5 * v
+		}
+	}
+	return 0
+}
+
+// MaxiSmallStraight scores the sum of the five lowest faces.
+func MaxiSmallStraight(game *Game) int {
+	if hasStraight(game.Dices, 1, 5) {
+		return straightSum(1, 5)
+	}
+	return 0
+}
+
+// MaxiLargeStraight scores the sum of the five highest faces.
+func MaxiLargeStraight(game *Game) int {
+	from := game.Faces() - 4
+	if hasStraight(game.Dices, from, 5) {
+		return straightSum(from, 5)
+	}
+	return 0
+}
+
+// MaxiFullStraight scores the sum of the highest six faces in a row.
+func MaxiFullStraight(game *Game) int {
+	for from := game.Faces() - 5; from >= 1; from-- {
+		if hasStraight(game.Dices, from, 6) {
+			return straightSum(from, 6)
+		}
+	}
+	return 0
+}
+
+func MaxiCastle(game *Game) int {
+	triples := []int{}
+	for v := game.Faces(); v >= 1; v-- {
+		if countDice(v, game.Dices) >= 3 {
+			triples = append(triples, v)
+		}
+	}
+	if len(triples) < 2 {
+		return 0
+	}
+	return 3*triples[0] + 3*triples[1]
+}
+
+func MaxiTower(game *Game) int {
+	four, two := 0, 0
+	for v := game.Faces(); v >= 1; v-- {
+		if four == 0 && countDice(v, game.Dices) >= 4 {
+			four = v
+		}
+	}
+	for v := game.Faces(); v >= 1; v-- {
+		if two == 0 && v != four && countDice(v, game.Dices) >= 2 {
+			two = v
+		}
+	}
+
+	if four == 0 || two == 0 {
+		return 0
+	}
+	return 4*four + 2*two
+}
+
+func MaxiChance(game *Game) int {
+	s := 0
+	for _, d := range game.Dices {
+		s += d.Value
+	}
+	return s
+}
+
+func MaxiMaxiYahtzee(game *Game) int {
+	for _, c := range occurrences(game.Dices) {
+		if c >= 6 {
+			return 100
+		}
+	}
+	return 0
+}
+
+// Poker Dice
+
+var pokerDiceScorer = Scorers{
+	Hand: PokerDiceHand,
+}
+
+func NewPokerDiceScorer() Scorers {
+	scorer := Scorers{}
+	for key, value := range pokerDiceScorer {
+		scorer[key] = value
+	}
+	return scorer
+}
+
+// PokerDiceHand returns the position of the hand's rank in HandRanks.
+func PokerDiceHand(game *Game) int {
+	return NewPokerHand(game.Dices).Rank.Value()
+}
+
+// PokerDiceRoundAction gives a won round to the players with the best hand
+// after the last player of the round showed the hand.
+func PokerDiceRoundAction(g *Game) {
+	if g.CurrentPlayer != len(g.Players)-1 {
+		return
+	}
+	for _, u := range g.RoundWinners(g.Round) {
+		for _, p := range g.Players {
+			if p.User == u {
+				p.ScoreSheet[RoundsWon]++
+			}
+		}
+	}
+}
+
+// pairValues returns the values having at least two dices in descending order.
+func pairValues(dices []*Dice, faces int) []int {
+	res := []int{}
+	for v := faces; v >= 1; v-- {
+		if countDice(v, dices) >= 2 {
+			res = append(res, v)
+		}
+	}
+	return res
+}
+
+func containsCategory(s []Category, e Category) bool {
+	for _, a := range s {
+		if a == e {
+			return true
+		}
+	}
+	return false
+}
+
 func countDice(value int, dices []*Dice) int {
 	c := 0
 	for _, d := range dices {
@@ -369,16 +798,57 @@ func max(a, b int) int {
 }
 
 func isYahtzee(dices []*Dice) bool {
-	for i := 1; i < 7; i++ {
-		sameCount := 0
-		for j := 0; j < len(dices); j++ {
-			if dices[j].Value == i {
-				sameCount++
-			}
-		}
-		if sameCount >= 5 {
+	for _, c := range occurrences(dices) {
+		if c >= 5 {
 			return true
 		}
 	}
 	return false
 }
+
+// occurrences returns how many dices have each value.
+func occurrences(dices []*Dice) map[int]int {
+	res := map[int]int{}
+	for _, d := range dices {
+		res[d.Value]++
+	}
+	return res
+}
+
+// hasStraight tells if the dices have every value from `from` to
+// `from+length-1`.
+func hasStraight(dices []*Dice, from, length int) bool {
+	if from < 1 {
+		return false
+	}
+	hit := occurrences(dices)
+	for v := from; v < from+length; v++ {
+		if hit[v] == 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// longestStraight returns the length of the longest run of values the dices
+// have.
+func longestStraight(dices []*Dice) int {
+	hit := occurrences(dices)
+	res := 0
+	for v := range hit {
+		if hit[v-1] > 0 {
+			continue
+		}
+		length := 0
+		for hit[v+length] > 0 {
+			length++
+		}
+		res = max(res, length)
+	}
+	return res
+}
+
+// straightSum returns the sum of the values from `from` to `from+length-1`.
+func straightSum(from, length int) int {
+	return length * (2*from + length - 1) / 2
+}
@@ -0,0 +1,109 @@
+package yahtzee
+
+import "errors"
+
+// ErrWaitingForPlayers is returned when a player acts after scoring in the
+// round of a Simultaneous game while the others are still playing.
+var ErrWaitingForPlayers = errors.New("waiting for the other players")
+
+// ViewAs makes the user the current player of a Simultaneous game with the
+// dices and the roll count of the user, so the actions and the scorers work on
+// them. The returned function moves the changes back to the player and
+// restores the game. Other games are left unchanged.
+func (g *Game) ViewAs(u User) (func(), error) {
+	if !g.HasFeature(Simultaneous) {
+		return func() {}, nil
+	}
+	if len(g.Players) == 0 {
+		return nil, ErrNoPlayers
+	}
+
+	index := -1
+	for i, p := range g.Players {
+		if p.User == u {
+			index = i
+		}
+	}
+	if index < 0 {
+		return nil, ErrNotYourTurn
+	}
+
+	p := g.Players[index]
+	current, dices, rollCount := g.CurrentPlayer, g.Dices, g.RollCount
+	g.CurrentPlayer, g.Dices, g.RollCount = index, p.Dices, p.RollCount
+
+	return func() {
+		p.Dices, p.RollCount = g.Dices, g.RollCount
+		g.CurrentPlayer, g.Dices, g.RollCount = current, dices, rollCount
+	}, nil
+}
+
+// DicesOf returns the dices the player plays with: the own dices of the player
+// in a Simultaneous game, the dices of the game otherwise.
+func (g *Game) DicesOf(p *Player) []*Dice {
+	if g.HasFeature(Simultaneous) {
+		return p.Dices
+	}
+	return g.Dices
+}
+
+// RollCountOf returns how many times the player rolled in the turn.
+func (g *Game) RollCountOf(p *Player) int {
+	if g.HasFeature(Simultaneous) {
+		return p.RollCount
+	}
+	return g.RollCount
+}
+
+// Player returns the player of the user, or nil when the user doesn't play
+// the game.
+func (g *Game) Player(u User) *Player {
+	for _, p := range g.Players {
+		if p.User == u {
+			return p
+		}
+	}
+	return nil
+}
+
+// newDices returns unlocked dices like the ones of the game.
+func (g *Game) newDices() []*Dice {
+	res := make([]*Dice, len(g.Dices))
+	for i := range res {
+		res[i] = &Dice{
+			Value: 1,
+			Face:  g.FaceName(1),
+		}
+	}
+	return res
+}
+
+// finishSimultaneousTurn marks that the current player scored in the round,
+// and starts the next round when every player did.
+func (g *Game) finishSimultaneousTurn() {
+	g.Players[g.CurrentPlayer].Scored = true
+	for _, p := range g.Players {
+		if !p.Scored {
+			return
+		}
+	}
+
+	g.Round++
+	for _, p := range g.Players {
+		p.Scored = false
+	}
+}
+
+// started tells if the game was started or any of the players acted in it
+// already.
+func (g *Game) started() bool {
+	if g.Started || g.CurrentPlayer > 0 || g.Round > 0 {
+		return true
+	}
+	for _, p := range g.Players {
+		if p.RollCount > 0 || p.Scored {
+			return true
+		}
+	}
+	return false
+}
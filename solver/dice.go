@@ -0,0 +1,58 @@
+package solver
+
+import (
+	"github.com/akarasz/yahtzee"
+	"github.com/akarasz/yahtzee/internal/dice"
+)
+
+// combinations has the combinations of the dices of a game with the dices to
+// score each of them with.
+type combinations struct {
+	*dice.Combinations
+
+	dices [][]*yahtzee.Dice
+}
+
+func newCombinations(n, faces int) *combinations {
+	c := &combinations{
+		Combinations: dice.New(n, faces),
+	}
+
+	c.dices = make([][]*yahtzee.Dice, len(c.States))
+	for i, s := range c.States {
+		for v, count := range s {
+			for j := 0; j < count; j++ {
+				c.dices[i] = append(c.dices[i], &yahtzee.Dice{Value: v + 1})
+			}
+		}
+	}
+
+	return c
+}
+
+// expected returns the expected values of rolling the dices beside each hold
+// when the combinations after the roll are worth `values`.
+func (c *combinations) expected(values []float64) []float64 {
+	res := make([]float64, len(c.Holds))
+	for i := range c.Holds {
+		for _, o := range c.Outcomes[i] {
+			res[i] += o.Probability * values[o.State]
+		}
+	}
+	return res
+}
+
+// best returns the value of each combination when the best hold is kept from
+// it, with the hold values in `holds`.
+func (c *combinations) best(holds []float64) []float64 {
+	res := make([]float64, len(c.States))
+	for i := range c.States {
+		res[i] = holds[c.SubHolds[i][0]]
+		for _, h := range c.SubHolds[i] {
+			if holds[h] > res[i] {
+				res[i] = holds[h]
+			}
+		}
+	}
+	return res
+}
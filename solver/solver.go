@@ -0,0 +1,298 @@
+// Package solver computes the optimal strategy of a single player game by
+// dynamic programming over the states of the score sheet.
+package solver
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"sync"
+
+	"github.com/akarasz/yahtzee"
+	"github.com/akarasz/yahtzee/internal/dice"
+)
+
+var (
+	// ErrUnsupported is returned when the strategy of a game with the features
+	// depends on more than the state of a single score sheet, or the points
+	// depend on the roll the dices were scored with.
+	ErrUnsupported = errors.New("features not supported by the solver")
+
+	// ErrNoTable is returned when no solver was registered for a game.
+	ErrNoTable = errors.New("no solver table for the game")
+
+	// ErrInvalidDices is returned when the dices can't be rolled in the game.
+	ErrInvalidDices = errors.New("invalid dices")
+)
+
+// Solver knows the expected points of the states of a game played with the
+// features and the options. The values are calculated when they are first
+// needed, and they are kept in the table of the solver.
+type Solver struct {
+	mu sync.Mutex
+
+	features []yahtzee.Feature
+	options  yahtzee.GameOptions
+
+	base       *yahtzee.Game
+	ruleset    *yahtzee.Ruleset
+	categories []yahtzee.Category
+	upper      []yahtzee.Category
+	rounds     int
+	rolls      int
+	lowTotal   bool
+
+	dices *combinations
+	table map[State]float64
+}
+
+// New returns the solver of the games played with the features and the
+// options.
+func New(options yahtzee.GameOptions, features ...yahtzee.Feature) (*Solver, error) {
+	features, err := yahtzee.ResolveFeatures(features...)
+	if err != nil {
+		return nil, err
+	}
+	if err := options.Validate(features...); err != nil {
+		return nil, err
+	}
+
+	g := yahtzee.NewGameWithOptions(options, features...)
+	ruleset := g.Ruleset()
+	if g.NumberOfColumns() > 1 || ruleset.Hands > 0 || g.HasFeature(yahtzee.SavedRolls) || len(g.Categories()) > 32 {
+		return nil, ErrUnsupported
+	}
+	// the served combinations of Generala depend on the roll they were made
+	// with, which is not part of the state
+	if g.HasFeature(yahtzee.GeneralaRules) {
+		return nil, ErrUnsupported
+	}
+	if dice.Count(len(g.Dices), g.Faces(), yahtzee.MaxCombinations) > yahtzee.MaxCombinations {
+		return nil, yahtzee.ErrTooManyCombinations
+	}
+
+	return &Solver{
+		features:   features,
+		options:    options,
+		base:       g,
+		ruleset:    ruleset,
+		categories: g.Categories(),
+		upper:      yahtzee.UpperCategories(ruleset.Faces),
+		rounds:     g.Rounds(),
+		rolls:      g.RollsPerTurn(),
+		lowTotal:   len(g.Scorer.PostGameActions) > 0,
+		dices:      newCombinations(len(g.Dices), g.Faces()),
+		table:      map[State]float64{},
+	}, nil
+}
+
+var (
+	registryLock sync.Mutex
+	registry     = map[string]*Solver{}
+)
+
+// Register makes For return the solver for the games with its features and
+// options, eg. after its table was read from a file. The states missing from
+// the table are solved first, so the solver never has to solve while a game
+// waits for it.
+func Register(s *Solver) {
+	s.Solve()
+
+	registryLock.Lock()
+	defer registryLock.Unlock()
+
+	registry[registryKey(s.features, s.options)] = s
+}
+
+// For returns the registered solver of the game. It returns ErrNoTable when
+// the game is supported but no solver was registered for its features and
+// options.
+func For(g *yahtzee.Game) (*Solver, error) {
+	options := yahtzee.GameOptions{}
+	if g.Options != nil {
+		options = *g.Options
+	}
+	s, err := New(options, g.Features...)
+	if err != nil {
+		return nil, err
+	}
+
+	registryLock.Lock()
+	defer registryLock.Unlock()
+
+	res, ok := registry[registryKey(s.features, s.options)]
+	if !ok {
+		return nil, ErrNoTable
+	}
+	return res, nil
+}
+
+// registryKey identifies the games with the features and the options. The
+// order of the features doesn't change the game.
+func registryKey(features []yahtzee.Feature, options yahtzee.GameOptions) string {
+	sorted := append([]yahtzee.Feature{}, features...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+	return fmt.Sprintf("%v %+v", sorted, options)
+}
+
+// Features returns the features of the games the solver plays.
+func (s *Solver) Features() []yahtzee.Feature {
+	return s.features
+}
+
+// Options returns the options of the games the solver plays.
+func (s *Solver) Options() yahtzee.GameOptions {
+	return s.options
+}
+
+// Solve calculates the value of every state a game can get into. It takes a
+// while for the full score sheets; the table can be saved and loaded later.
+func (s *Solver) Solve() float64 {
+	return s.Value(map[yahtzee.Category]int{})
+}
+
+// Value returns the expected points of the rest of the game from the score
+// sheet when the player plays the best way.
+func (s *Solver) Value(sheet map[yahtzee.Category]int) float64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.value(s.StateOf(sheet))
+}
+
+// Len returns how many states are in the table.
+func (s *Solver) Len() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return len(s.table)
+}
+
+func (s *Solver) value(st State) float64 {
+	if v, ok := s.table[st]; ok {
+		return v
+	}
+
+	var v float64
+	g := s.gameIn(st)
+	if open := s.open(g, st); len(open) == 0 {
+		v = s.postGame(g)
+	} else {
+		v = s.turn(s.terminal(g, open)).start()
+	}
+
+	s.table[st] = v
+	return v
+}
+
+// terminal returns the value of each combination of the dices when the best
+// category is scored with them.
+func (s *Solver) terminal(g *yahtzee.Game, open []yahtzee.Category) []float64 {
+	res := make([]float64, len(s.dices.States))
+	for d := range s.dices.States {
+		res[d] = math.Inf(-1)
+		for _, c := range open {
+			if v, ok := s.categoryValue(g, c, d); ok && v > res[d] {
+				res[d] = v
+			}
+		}
+		if math.IsInf(res[d], -1) {
+			res[d] = 0
+		}
+	}
+	return res
+}
+
+// categoryValue returns the points of scoring the combination in the category
+// with the value of the state after that.
+func (s *Solver) categoryValue(g *yahtzee.Game, c yahtzee.Category, d int) (float64, bool) {
+	points, next, ok := s.score(g, c, d)
+	if !ok {
+		return 0, false
+	}
+	return float64(points) + s.value(next), true
+}
+
+// score scores the combination in the category with the scorer and the
+// actions of the game, and returns the points it's worth with the state of the
+// score sheet after that.
+func (s *Solver) score(g *yahtzee.Game, c yahtzee.Category, d int) (int, State, bool) {
+	g.Dices = s.dices.dices[d]
+	if g.CheckScoreRules(c) != nil {
+		return 0, State{}, false
+	}
+
+	p := g.Players[0]
+	sheet := p.ScoreSheet
+	defer func() { p.ScoreSheet = sheet }()
+
+	p.ScoreSheet = make(map[yahtzee.Category]int, len(sheet)+1)
+	for k, v := range sheet {
+		p.ScoreSheet[k] = v
+	}
+
+	for _, action := range g.Scorer.PreScoreActions {
+		action(g)
+	}
+	p.ScoreSheet[c] = g.Scorer.ScoreActions[c](g)
+	for _, action := range g.Scorer.PostScoreActions {
+		action(g)
+	}
+
+	return total(p.ScoreSheet) - total(sheet), s.StateOf(p.ScoreSheet), true
+}
+
+// postGame returns the points the post-game actions give in the state.
+func (s *Solver) postGame(g *yahtzee.Game) float64 {
+	p := g.Players[0]
+	before := total(p.ScoreSheet)
+	for _, action := range g.Scorer.PostGameActions {
+		action(g)
+	}
+	return float64(total(p.ScoreSheet) - before)
+}
+
+// gameIn returns a single player game with a score sheet in the state, after
+// the last roll of the turn.
+func (s *Solver) gameIn(st State) *yahtzee.Game {
+	g := *s.base
+	g.Players = []*yahtzee.Player{
+		{
+			User:       yahtzee.User("solver"),
+			ScoreSheet: s.sheet(st),
+		},
+	}
+	g.Teams = nil
+	g.CurrentPlayer = 0
+	g.Round = 0
+	for i := range s.categories {
+		if st.used(i) {
+			g.Round++
+		}
+	}
+	g.RollCount = s.rolls
+	g.Context = map[string]interface{}{}
+	return &g
+}
+
+// open returns the categories that can be scored in the state.
+func (s *Solver) open(g *yahtzee.Game, st State) []yahtzee.Category {
+	res := []yahtzee.Category{}
+	if g.Round >= s.rounds {
+		return res
+	}
+	for i, c := range s.categories {
+		if st.used(i) {
+			continue
+		}
+		if _, ok := g.Scorer.ScoreActions[c]; !ok {
+			continue
+		}
+		if g.HasFeature(yahtzee.Ordered) && g.OrderedCategory() != c {
+			continue
+		}
+		res = append(res, c)
+	}
+	return res
+}
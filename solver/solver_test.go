@@ -0,0 +1,155 @@
+package solver_test
+
+import (
+	"bytes"
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/akarasz/yahtzee"
+	"github.com/akarasz/yahtzee/solver"
+)
+
+// sheetWithout returns a score sheet with every category scored with zero
+// points but the open ones.
+func sheetWithout(open ...yahtzee.Category) map[yahtzee.Category]int {
+	res := map[yahtzee.Category]int{}
+	for _, c := range yahtzee.Categories() {
+		res[c] = 0
+	}
+	for _, c := range open {
+		delete(res, c)
+	}
+	return res
+}
+
+func TestLastCategory(t *testing.T) {
+	s, err := solver.New(yahtzee.GameOptions{})
+	require.Nil(t, err)
+
+	assert.InDelta(t, 23.33, s.Value(sheetWithout(yahtzee.Chance)), 0.01)
+	assert.InDelta(t, 50*0.04603, s.Value(sheetWithout(yahtzee.Yahtzee)), 0.001)
+	assert.Equal(t, 0.0, s.Value(sheetWithout()))
+}
+
+func TestUpperSectionBonus(t *testing.T) {
+	s, err := solver.New(yahtzee.GameOptions{})
+	require.Nil(t, err)
+
+	withoutBonus := sheetWithout(yahtzee.Sixes)
+	withBonus := sheetWithout(yahtzee.Sixes)
+	withBonus[yahtzee.Fives] = 25
+	withBonus[yahtzee.Fours] = 20
+
+	// the bonus needs three sixes, and every six is kept for it
+	p := 1 - math.Pow(5.0/6, 3)
+	atLeastThree := 10*math.Pow(p, 3)*math.Pow(1-p, 2) + 5*math.Pow(p, 4)*(1-p) + math.Pow(p, 5)
+
+	assert.InDelta(t, 35*atLeastThree, s.Value(withBonus)-s.Value(withoutBonus), 0.001)
+}
+
+func TestYahtzeeBonus(t *testing.T) {
+	s, err := solver.New(yahtzee.GameOptions{}, yahtzee.YahtzeeBonus)
+	require.Nil(t, err)
+
+	sheet := sheetWithout(yahtzee.Chance)
+	eligible := sheetWithout(yahtzee.Chance)
+	eligible[yahtzee.Yahtzee] = 50
+
+	assert.True(t, s.Value(eligible) > s.Value(sheet))
+}
+
+func TestTurn(t *testing.T) {
+	s, err := solver.New(yahtzee.GameOptions{})
+	require.Nil(t, err)
+
+	turn := s.Turn(sheetWithout(yahtzee.Yahtzee, yahtzee.Chance))
+	assert.InDelta(t, s.Value(sheetWithout(yahtzee.Yahtzee, yahtzee.Chance)), turn.Value(), 0.000001)
+
+	hold, value, err := turn.Hold([]int{6, 2, 6, 3, 6}, 2)
+	require.Nil(t, err)
+	assert.Equal(t, []int{0, 2, 4}, hold)
+
+	kept, err := turn.HoldValue([]int{6, 2, 6, 3, 6}, []int{0, 2, 4}, 2)
+	require.Nil(t, err)
+	assert.Equal(t, value, kept)
+
+	category, points, err := turn.Category([]int{1, 1, 2, 2, 3})
+	require.Nil(t, err)
+	assert.Equal(t, yahtzee.Yahtzee, category)
+	assert.InDelta(t, 23.33, points, 0.01)
+
+	categories, err := turn.Categories([]int{5, 5, 5, 5, 5})
+	require.Nil(t, err)
+	assert.InDelta(t, 50+23.33, categories[yahtzee.Yahtzee], 0.01)
+	assert.InDelta(t, 25+50*0.04603, categories[yahtzee.Chance], 0.001)
+
+	_, _, err = turn.Hold([]int{6, 6, 6}, 2)
+	assert.Exactly(t, solver.ErrInvalidDices, err)
+}
+
+func TestSaveLoad(t *testing.T) {
+	s, err := solver.New(yahtzee.GameOptions{})
+	require.Nil(t, err)
+	sheet := sheetWithout(yahtzee.Ones, yahtzee.Chance)
+	value := s.Value(sheet)
+
+	var buf bytes.Buffer
+	require.Nil(t, s.Save(&buf))
+	saved := buf.Bytes()
+
+	loaded, err := solver.New(yahtzee.GameOptions{})
+	require.Nil(t, err)
+	require.Nil(t, loaded.Load(bytes.NewReader(saved)))
+	assert.Equal(t, s.Len(), loaded.Len())
+	assert.Equal(t, value, loaded.Value(sheet))
+
+	read, err := solver.Read(bytes.NewReader(saved))
+	require.Nil(t, err)
+	assert.Equal(t, s.Len(), read.Len())
+	assert.Equal(t, value, read.Value(sheet))
+
+	other, err := solver.New(yahtzee.GameOptions{}, yahtzee.YahtzeeBonus)
+	require.Nil(t, err)
+	assert.Exactly(t, solver.ErrTableMismatch, other.Load(bytes.NewReader(saved)))
+
+	// more entries than states
+	corrupt := append([]byte{}, saved...)
+	count := bytes.IndexByte(corrupt, '{')
+	count += bytes.IndexByte(corrupt[count:], '\n') + 1
+	copy(corrupt[count:], []byte{0xff, 0xff, 0xff, 0xff})
+	_, err = solver.Read(bytes.NewReader(corrupt))
+	assert.Exactly(t, solver.ErrInvalidTable, err)
+}
+
+func TestUnsupported(t *testing.T) {
+	_, err := solver.New(yahtzee.GameOptions{}, yahtzee.Triple)
+	assert.Exactly(t, solver.ErrUnsupported, err)
+
+	_, err = solver.New(yahtzee.GameOptions{}, yahtzee.PokerDice)
+	assert.Exactly(t, solver.ErrUnsupported, err)
+
+	_, err = solver.New(yahtzee.GameOptions{}, yahtzee.GeneralaRules)
+	assert.Exactly(t, solver.ErrUnsupported, err)
+}
+
+func TestFor(t *testing.T) {
+	options := yahtzee.GameOptions{Rounds: 1}
+	g := yahtzee.NewGameWithOptions(options, yahtzee.TheChance, yahtzee.YahtzeeBonus)
+
+	_, err := solver.For(g)
+	assert.Exactly(t, solver.ErrNoTable, err)
+
+	s, err := solver.New(options, yahtzee.YahtzeeBonus, yahtzee.TheChance)
+	require.Nil(t, err)
+	solver.Register(s)
+
+	got, err := solver.For(g)
+	require.Nil(t, err)
+	assert.Same(t, s, got)
+
+	_, err = solver.For(yahtzee.NewGame(yahtzee.Triple))
+	assert.Exactly(t, solver.ErrUnsupported, err)
+}
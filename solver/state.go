@@ -0,0 +1,117 @@
+package solver
+
+import "github.com/akarasz/yahtzee"
+
+// LowTotal is the highest total the states tell apart in the games with
+// post-game actions; The Chance gives its bonus for a total of 5 points.
+const LowTotal = 5
+
+// State is what the rest of a game depends on in a score sheet.
+type State struct {
+	// Used has a bit for every scored category in the order of the ruleset's
+	// categories
+	Used uint32
+
+	// Upper is the total of the upper section, at most the threshold of the
+	// bonus
+	Upper int
+
+	// Yahtzee shows if the yahtzee was scored with points, which makes the
+	// next yahtzees eligible for the bonus
+	Yahtzee bool
+
+	// Low is the total of the score sheet while it's at most LowTotal, and
+	// LowTotal+1 above that. It's only kept in the games with post-game
+	// actions.
+	Low int
+}
+
+// StateOf returns the state of the score sheet.
+func (s *Solver) StateOf(sheet map[yahtzee.Category]int) State {
+	res := State{}
+	for i, c := range s.categories {
+		if _, ok := sheet[c]; ok {
+			res.Used |= 1 << uint(i)
+		}
+	}
+	for _, c := range s.upper {
+		res.Upper += sheet[c]
+	}
+	if res.Upper > s.ruleset.UpperSectionThreshold {
+		res.Upper = s.ruleset.UpperSectionThreshold
+	}
+	res.Yahtzee = sheet[yahtzee.Yahtzee] > 0
+	if s.lowTotal {
+		res.Low = min(total(sheet), LowTotal+1)
+	}
+	return res
+}
+
+// used tells if the category is scored in the state.
+func (st State) used(i int) bool {
+	return st.Used&(1<<uint(i)) != 0
+}
+
+// sheet returns a score sheet in the state. The scores are made up, but the
+// scorers and the actions of the game give the same points for it as for
+// any other sheet in the state.
+func (s *Solver) sheet(st State) map[yahtzee.Category]int {
+	res := map[yahtzee.Category]int{}
+	var upper, lower []yahtzee.Category
+	for i, c := range s.categories {
+		if !st.used(i) {
+			continue
+		}
+		res[c] = 0
+		if containsCategory(s.upper, c) {
+			upper = append(upper, c)
+		} else if c != yahtzee.Yahtzee {
+			lower = append(lower, c)
+		}
+	}
+
+	if len(upper) > 0 {
+		res[upper[0]] = st.Upper
+	}
+	if s.ruleset.UpperSectionBonus > 0 {
+		if st.Upper >= s.ruleset.UpperSectionThreshold {
+			res[yahtzee.Bonus] = s.ruleset.UpperSectionBonus
+		} else if len(upper) == len(s.upper) {
+			res[yahtzee.Bonus] = 0
+		}
+	}
+	if _, ok := res[yahtzee.Yahtzee]; ok && st.Yahtzee {
+		res[yahtzee.Yahtzee] = 50
+	}
+	if s.lowTotal && len(lower) > 0 {
+		if missing := min(st.Low, LowTotal+1) - total(res); missing > 0 {
+			res[lower[0]] += missing
+		}
+	}
+
+	return res
+}
+
+func total(sheet map[yahtzee.Category]int) int {
+	res := 0
+	for _, v := range sheet {
+		res += v
+	}
+	return res
+}
+
+func containsCategory(s []yahtzee.Category, c yahtzee.Category) bool {
+	for _, a := range s {
+		if a == c {
+			return true
+		}
+	}
+	return false
+}
+
+func min(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,179 @@
+package solver
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"io"
+
+	"github.com/akarasz/yahtzee"
+)
+
+// magic starts the saved tables.
+const magic = "yahtzee-solver-1\n"
+
+var (
+	// ErrTableMismatch is returned when the table was saved by a solver of
+	// other features or options.
+	ErrTableMismatch = errors.New("table of another game")
+
+	// ErrInvalidTable is returned when the table has more entries than the
+	// states of the game.
+	ErrInvalidTable = errors.New("invalid table")
+)
+
+type header struct {
+	Features []yahtzee.Feature
+	Options  yahtzee.GameOptions
+}
+
+type entry struct {
+	Used    uint32
+	Upper   uint16
+	Yahtzee uint8
+	Low     uint8
+	Value   float64
+}
+
+// Save writes the table of the solver.
+func (s *Solver) Save(w io.Writer) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	b := bufio.NewWriter(w)
+	if _, err := b.WriteString(magic); err != nil {
+		return err
+	}
+	h, err := json.Marshal(s.header())
+	if err != nil {
+		return err
+	}
+	if _, err := b.Write(append(h, '\n')); err != nil {
+		return err
+	}
+
+	if err := binary.Write(b, binary.LittleEndian, uint32(len(s.table))); err != nil {
+		return err
+	}
+	for st, v := range s.table {
+		e := entry{
+			Used:  st.Used,
+			Upper: uint16(st.Upper),
+			Low:   uint8(st.Low),
+			Value: v,
+		}
+		if st.Yahtzee {
+			e.Yahtzee = 1
+		}
+		if err := binary.Write(b, binary.LittleEndian, &e); err != nil {
+			return err
+		}
+	}
+
+	return b.Flush()
+}
+
+// Load reads a table saved by a solver of the same features and options into
+// the table of the solver.
+func (s *Solver) Load(r io.Reader) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	b := bufio.NewReader(r)
+	line, err := readHeader(b)
+	if err != nil {
+		return err
+	}
+	h, err := json.Marshal(s.header())
+	if err != nil {
+		return err
+	}
+	if !bytes.Equal(line, h) {
+		return ErrTableMismatch
+	}
+
+	return s.readEntries(b)
+}
+
+// Read returns the solver of a saved table with the features and the options
+// it was saved with.
+func Read(r io.Reader) (*Solver, error) {
+	b := bufio.NewReader(r)
+	line, err := readHeader(b)
+	if err != nil {
+		return nil, err
+	}
+	var h header
+	if err := json.Unmarshal(line, &h); err != nil {
+		return nil, err
+	}
+
+	s, err := New(h.Options, h.Features...)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.readEntries(b); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// readHeader checks the magic and returns the header line of the table.
+func readHeader(b *bufio.Reader) ([]byte, error) {
+	m := make([]byte, len(magic))
+	if _, err := io.ReadFull(b, m); err != nil {
+		return nil, err
+	}
+	if string(m) != magic {
+		return nil, ErrTableMismatch
+	}
+	line, err := b.ReadBytes('\n')
+	if err != nil {
+		return nil, err
+	}
+	return bytes.TrimSpace(line), nil
+}
+
+func (s *Solver) readEntries(b *bufio.Reader) error {
+	var n uint32
+	if err := binary.Read(b, binary.LittleEndian, &n); err != nil {
+		return err
+	}
+	if uint64(n) > s.states() {
+		return ErrInvalidTable
+	}
+	entries := make([]entry, n)
+	if err := binary.Read(b, binary.LittleEndian, entries); err != nil {
+		return err
+	}
+	for _, e := range entries {
+		s.table[State{
+			Used:    e.Used,
+			Upper:   int(e.Upper),
+			Yahtzee: e.Yahtzee != 0,
+			Low:     int(e.Low),
+		}] = e.Value
+	}
+
+	return nil
+}
+
+// states returns how many states the games of the solver have.
+func (s *Solver) states() uint64 {
+	res := uint64(1) << uint(len(s.categories))
+	res *= uint64(s.ruleset.UpperSectionThreshold + 1)
+	res *= 2
+	if s.lowTotal {
+		res *= LowTotal + 2
+	}
+	return res
+}
+
+func (s *Solver) header() header {
+	return header{
+		Features: s.features,
+		Options:  s.options,
+	}
+}
@@ -0,0 +1,172 @@
+package solver
+
+import (
+	"math"
+
+	"github.com/akarasz/yahtzee"
+	"github.com/akarasz/yahtzee/internal/dice"
+)
+
+// Turn knows the best plays of a turn started from a score sheet.
+type Turn struct {
+	solver *Solver
+	game   *yahtzee.Game
+	open   []yahtzee.Category
+
+	// levels has the value of every combination of the dices with as many
+	// rolls left as the index
+	levels [][]float64
+}
+
+// Turn returns the turn started from the score sheet.
+func (s *Solver) Turn(sheet map[yahtzee.Category]int) *Turn {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	st := s.StateOf(sheet)
+	g := s.gameIn(st)
+	open := s.open(g, st)
+
+	t := s.turn(s.terminal(g, open))
+	t.game = g
+	t.open = open
+	return t
+}
+
+func (s *Solver) turn(terminal []float64) *Turn {
+	t := &Turn{
+		solver: s,
+		levels: [][]float64{terminal},
+	}
+	for k := 1; k < s.rolls; k++ {
+		t.level(k)
+	}
+	return t
+}
+
+// level returns the values of the combinations with `rolls` rolls left.
+func (t *Turn) level(rolls int) []float64 {
+	for len(t.levels) <= rolls {
+		c := t.solver.dices
+		t.levels = append(t.levels, c.best(c.expected(t.levels[len(t.levels)-1])))
+	}
+	return t.levels[rolls]
+}
+
+// start returns the expected points before the first roll of the turn.
+func (t *Turn) start() float64 {
+	return t.solver.dices.expected(t.level(t.solver.rolls - 1))[t.solver.dices.EmptyHold]
+}
+
+// Value returns the expected points of the rest of the game before the first
+// roll of the turn.
+func (t *Turn) Value() float64 {
+	return t.start()
+}
+
+// DicesValue returns the expected points of the rest of the game with the
+// dices rolled when `rollsLeft` rolls are left in the turn.
+func (t *Turn) DicesValue(dices []int, rollsLeft int) (float64, error) {
+	d, ok := t.solver.dices.StateOf(dices)
+	if !ok || rollsLeft < 0 {
+		return 0, ErrInvalidDices
+	}
+	return t.level(rollsLeft)[d], nil
+}
+
+// Hold returns the indices of the dices to keep for the next roll with the
+// expected points of the rest of the game after that, when `rollsLeft` rolls
+// are left in the turn.
+func (t *Turn) Hold(dices []int, rollsLeft int) ([]int, float64, error) {
+	d, ok := t.solver.dices.StateOf(dices)
+	if !ok || rollsLeft < 1 {
+		return nil, 0, ErrInvalidDices
+	}
+
+	c := t.solver.dices
+	holds := c.expected(t.level(rollsLeft - 1))
+	best := c.SubHolds[d][0]
+	for _, h := range c.SubHolds[d] {
+		if holds[h] > holds[best] {
+			best = h
+		}
+	}
+	return indices(dices, c.Holds[best]), holds[best], nil
+}
+
+// HoldValue returns the expected points of the rest of the game when the dices
+// with the indices in `hold` are kept for the next roll.
+func (t *Turn) HoldValue(dices []int, hold []int, rollsLeft int) (float64, error) {
+	c := t.solver.dices
+	if _, ok := c.StateOf(dices); !ok || rollsLeft < 1 {
+		return 0, ErrInvalidDices
+	}
+
+	kept := make(dice.Counts, c.Faces)
+	for _, i := range hold {
+		if i < 0 || i >= len(dices) {
+			return 0, ErrInvalidDices
+		}
+		kept[dices[i]-1]++
+	}
+	h, ok := c.HoldIndex[c.Key(kept)]
+	if !ok {
+		return 0, ErrInvalidDices
+	}
+	return c.expected(t.level(rollsLeft - 1))[h], nil
+}
+
+// Categories returns the expected points of the rest of the game when the
+// dices are scored in each category the rules allow.
+func (t *Turn) Categories(dices []int) (map[yahtzee.Category]float64, error) {
+	s := t.solver
+	d, ok := s.dices.StateOf(dices)
+	if !ok {
+		return nil, ErrInvalidDices
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	res := map[yahtzee.Category]float64{}
+	for _, c := range t.open {
+		if v, ok := s.categoryValue(t.game, c, d); ok {
+			res[c] = v
+		}
+	}
+	return res, nil
+}
+
+// Category returns the category to score the dices in with the expected
+// points of the rest of the game after that.
+func (t *Turn) Category(dices []int) (yahtzee.Category, float64, error) {
+	values, err := t.Categories(dices)
+	if err != nil {
+		return "", 0, err
+	}
+
+	var res yahtzee.Category
+	best := math.Inf(-1)
+	for _, c := range t.open {
+		if v, ok := values[c]; ok && v > best {
+			res, best = c, v
+		}
+	}
+	if res == "" {
+		return "", 0, ErrInvalidDices
+	}
+	return res, best, nil
+}
+
+// indices returns the indices of the dices to keep for the hold.
+func indices(dices []int, hold dice.Counts) []int {
+	left := append(dice.Counts{}, hold...)
+	res := []int{}
+	for i, v := range dices {
+		if left[v-1] > 0 {
+			left[v-1]--
+			res = append(res, i)
+		}
+	}
+	return res
+}
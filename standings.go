@@ -0,0 +1,172 @@
+package yahtzee
+
+import "sort"
+
+// Standing shows where a player is on the leaderboard.
+type Standing struct {
+	// User who plays
+	User User
+
+	// Total is the sum of every score in the player's score sheet, or in the
+	// team's score sheet in the Teams mode
+	Total int
+
+	// Team is the name of the player's team in the Teams mode
+	Team string `json:",omitempty"`
+
+	// Handicap is the part of the total given by the handicap of the player,
+	// or of the team in the Teams mode
+	Handicap int `json:",omitempty"`
+
+	// Differences has the difference of the player's points from the best
+	// points of each round in a Duplicate game
+	Differences []int `json:",omitempty"`
+
+	// Place is the position of the player; players with the same total share
+	// the same place
+	Place int
+}
+
+// Totals returns the sum of every score, including the bonuses, for each
+// player.
+func (g *Game) Totals() map[User]int {
+	res := map[User]int{}
+	for _, p := range g.Players {
+		res[p.User] = g.totalWithHandicap(p)
+	}
+	return res
+}
+
+// Standings returns the players ordered by their totals. Players with equal
+// totals share the place and keep their joining order. The Winner of the game
+// is always on the first place. In the Teams mode the players have the total
+// of their team, and the teammates are listed together.
+func (g *Game) Standings() []Standing {
+	res := make([]Standing, 0, len(g.Players))
+	for _, p := range g.orderedByTeam() {
+		res = append(res, Standing{
+			User:     p.User,
+			Total:    g.totalWithHandicap(p),
+			Team:     p.Team,
+			Handicap: g.HandicapPoints(p),
+		})
+		if g.HasFeature(Duplicate) {
+			res[len(res)-1].Differences = g.differences(p)
+		}
+	}
+
+	won := func(s Standing) bool {
+		if g.Winner == "" {
+			return false
+		}
+		return s.User == g.Winner || (s.Team != "" && s.Team == g.winnerTeam())
+	}
+
+	sort.SliceStable(res, func(i, j int) bool {
+		if won(res[i]) != won(res[j]) {
+			return won(res[i])
+		}
+		return res[i].Total > res[j].Total
+	})
+
+	for i := range res {
+		if i > 0 && res[i].Total == res[i-1].Total && won(res[i]) == won(res[i-1]) {
+			res[i].Place = res[i-1].Place
+		} else {
+			res[i].Place = i + 1
+		}
+	}
+
+	return res
+}
+
+// totalWithHandicap returns the total of the player with the handicap points,
+// whether they are in the score sheet already or not.
+func (g *Game) totalWithHandicap(p *Player) int {
+	return g.PlayerTotal(p) - g.PlayerSheet(p, 0)[HandicapCategory] + g.HandicapPoints(p)
+}
+
+// orderedByTeam returns the players in the order of their teams, or in the
+// joining order when the game is not played in teams.
+func (g *Game) orderedByTeam() []*Player {
+	if len(g.Teams) == 0 {
+		return g.Players
+	}
+	res := []*Player{}
+	for _, t := range g.Teams {
+		for _, p := range g.Players {
+			if p.Team == t.Name {
+				res = append(res, p)
+			}
+		}
+	}
+	return res
+}
+
+// differences returns the difference of the player's line from the best line
+// in each round the player played.
+func (g *Game) differences(player *Player) []int {
+	res := make([]int, len(player.Lines))
+	for round, line := range player.Lines {
+		best := line
+		for _, p := range g.Players {
+			if round < len(p.Lines) && p.Lines[round] > best {
+				best = p.Lines[round]
+			}
+		}
+		res[round] = line - best
+	}
+	return res
+}
+
+// winnerTeam returns the team of the Winner.
+func (g *Game) winnerTeam() string {
+	for _, p := range g.Players {
+		if p.User == g.Winner {
+			return p.Team
+		}
+	}
+	return ""
+}
+
+// Winners returns the users on the first place when the game is over.
+func (g *Game) Winners() []User {
+	res := []User{}
+	if !g.IsOver() {
+		return res
+	}
+	for _, s := range g.Standings() {
+		if s.Place == 1 {
+			res = append(res, s.User)
+		}
+	}
+	return res
+}
+
+// Total returns the sum of the scores in the score sheet. With multiple
+// columns the sum of each column is multiplied by its position.
+func (p *Player) Total() int {
+	return totalOf(p.ScoreSheet, p.Columns)
+}
+
+// totalOf returns the sum of the single sheet, or the sum of the columns
+// multiplied by their positions when there are columns.
+func totalOf(sheet map[Category]int, columns []map[Category]int) int {
+	if len(columns) == 0 {
+		return sum(sheet)
+	}
+
+	s := 0
+	for i, column := range columns {
+		s += (i + 1) * sum(column)
+	}
+	return s
+}
+
+func sum(sheet map[Category]int) int {
+	s := 0
+	for _, v := range sheet {
+		s += v
+	}
+	return s
+}
@@ -13,6 +13,8 @@ import (
 // InMemory is the in-memory implementation of Store.
 type InMemory struct {
 	repo  map[string]yahtzee.Game
+	undos map[string]yahtzee.Undo
+	moves map[string][]yahtzee.Move
 	locks map[string]*sync.Mutex
 
 	repoLock  *sync.RWMutex
@@ -38,6 +40,59 @@ func (s *InMemory) Load(id string) (yahtzee.Game, error) {
 	return g, nil
 }
 
+func (s *InMemory) SaveUndo(id string, u yahtzee.Undo) error {
+	s.repoLock.Lock()
+	s.undos[id] = u
+	s.repoLock.Unlock()
+
+	return nil
+}
+
+func (s *InMemory) LoadUndo(id string) (yahtzee.Undo, error) {
+	s.repoLock.RLock()
+	u, ok := s.undos[id]
+	s.repoLock.RUnlock()
+	if !ok {
+		return u, store.ErrNotExists
+	}
+
+	return u, nil
+}
+
+func (s *InMemory) DeleteUndo(id string) error {
+	s.repoLock.Lock()
+	delete(s.undos, id)
+	s.repoLock.Unlock()
+
+	return nil
+}
+
+func (s *InMemory) AddMove(id string, m yahtzee.Move) error {
+	s.repoLock.Lock()
+	s.moves[id] = append(s.moves[id], m)
+	s.repoLock.Unlock()
+
+	return nil
+}
+
+func (s *InMemory) LoadMoves(id string) ([]yahtzee.Move, error) {
+	s.repoLock.RLock()
+	res := append([]yahtzee.Move{}, s.moves[id]...)
+	s.repoLock.RUnlock()
+
+	return res, nil
+}
+
+func (s *InMemory) RemoveLastMove(id string) error {
+	s.repoLock.Lock()
+	if n := len(s.moves[id]); n > 0 {
+		s.moves[id] = s.moves[id][:n-1]
+	}
+	s.repoLock.Unlock()
+
+	return nil
+}
+
 func (s *InMemory) Lock(id string) (func(), error) {
 	s.locksLock.Lock()
 	l, ok := s.locks[id]
@@ -58,6 +113,8 @@ func (s *InMemory) Lock(id string) (func(), error) {
 func New() *InMemory {
 	res := InMemory{
 		repo:  map[string]yahtzee.Game{},
+		undos: map[string]yahtzee.Undo{},
+		moves: map[string][]yahtzee.Move{},
 		locks: map[string]*sync.Mutex{},
 
 		repoLock:  &sync.RWMutex{},
@@ -55,7 +55,11 @@ func (r *Redis) Load(id string) (yahtzee.Game, error) {
 
 	err = json.Unmarshal(raw, &res)
 
-	res.Scorer = yahtzee.ComposeScorer(res.Features...)
+	var options yahtzee.GameOptions
+	if res.Options != nil {
+		options = *res.Options
+	}
+	res.Scorer = yahtzee.ComposeScorerWithOptions(options, res.Features...)
 	res.Context = map[string]interface{}{}
 
 	return res, err
@@ -70,6 +74,74 @@ func (r *Redis) Save(id string, g yahtzee.Game) error {
 	return r.client.Set(ctx, "game:"+id, string(raw), r.expiration).Err()
 }
 
+func (r *Redis) SaveUndo(id string, u yahtzee.Undo) error {
+	raw, err := json.Marshal(u)
+	if err != nil {
+		return err
+	}
+
+	return r.client.Set(ctx, "undo:"+id, string(raw), r.expiration).Err()
+}
+
+func (r *Redis) LoadUndo(id string) (yahtzee.Undo, error) {
+	var res yahtzee.Undo
+
+	raw, err := r.client.Get(ctx, "undo:"+id).Bytes()
+	if err != nil {
+		return yahtzee.Undo{}, store.ErrNotExists
+	}
+
+	err = json.Unmarshal(raw, &res)
+
+	var options yahtzee.GameOptions
+	if res.Game.Options != nil {
+		options = *res.Game.Options
+	}
+	res.Game.Scorer = yahtzee.ComposeScorerWithOptions(options, res.Game.Features...)
+	res.Game.Context = map[string]interface{}{}
+
+	return res, err
+}
+
+func (r *Redis) DeleteUndo(id string) error {
+	return r.client.Del(ctx, "undo:"+id).Err()
+}
+
+func (r *Redis) AddMove(id string, m yahtzee.Move) error {
+	raw, err := json.Marshal(m)
+	if err != nil {
+		return err
+	}
+
+	if err := r.client.RPush(ctx, "moves:"+id, string(raw)).Err(); err != nil {
+		return err
+	}
+	return r.client.Expire(ctx, "moves:"+id, r.expiration).Err()
+}
+
+func (r *Redis) LoadMoves(id string) ([]yahtzee.Move, error) {
+	raws, err := r.client.LRange(ctx, "moves:"+id, 0, -1).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]yahtzee.Move, len(raws))
+	for i, raw := range raws {
+		if err := json.Unmarshal([]byte(raw), &res[i]); err != nil {
+			return nil, err
+		}
+	}
+	return res, nil
+}
+
+func (r *Redis) RemoveLastMove(id string) error {
+	err := r.client.RPop(ctx, "moves:"+id).Err()
+	if err == redis.Nil {
+		return nil
+	}
+	return err
+}
+
 func (r *Redis) Lock(id string) (func(), error) {
 	lock, err := r.locker.Obtain(
 		context.Background(),
@@ -24,6 +24,25 @@ type Store interface {
 
 	// Lock reserves the `id` so another locking on the same would block.
 	Lock(id string) (func(), error)
+
+	// SaveUndo keeps the undo of the last action of the game.
+	SaveUndo(id string, u yahtzee.Undo) error
+
+	// LoadUndo returns the undo of the last action of the game.
+	LoadUndo(id string) (yahtzee.Undo, error)
+
+	// DeleteUndo drops the undo of the game, if there is any.
+	DeleteUndo(id string) error
+
+	// AddMove appends the move to the history of the game.
+	AddMove(id string, m yahtzee.Move) error
+
+	// LoadMoves returns the history of the game. It's empty for the games
+	// without moves.
+	LoadMoves(id string) ([]yahtzee.Move, error)
+
+	// RemoveLastMove drops the last move of the history of the game.
+	RemoveLastMove(id string) error
 }
 
 type TestSuite struct {
@@ -49,6 +68,8 @@ func (ts *TestSuite) TestLoad() {
 		ts.Exactly(saved.Features, got.Features)
 		ts.Exactly(saved.RollCount, got.RollCount)
 		ts.Exactly(saved.Round, got.Round)
+		ts.Exactly(saved.Seed, got.Seed)
+		ts.Exactly(saved.RollCounter, got.RollCounter)
 		ts.NotNil(got.Scorer)
 		ts.NotNil(got.Context)
 	}
@@ -79,6 +100,71 @@ func (ts *TestSuite) TestSave() {
 		ts.Exactly(advanced.Features, got.Features)
 		ts.Exactly(advanced.RollCount, got.RollCount)
 		ts.Exactly(advanced.Round, got.Round)
+		ts.Exactly(advanced.Seed, got.Seed)
+		ts.Exactly(advanced.RollCounter, got.RollCounter)
+	}
+}
+
+func (ts *TestSuite) TestUndo() {
+	s := ts.Subject
+
+	_, err := s.LoadUndo("ddddd")
+	ts.Exactly(ErrNotExists, err)
+
+	saved := yahtzee.Undo{
+		Game:      *ts.newAdvancedGame(),
+		User:      yahtzee.User("Bob"),
+		Requested: true,
+		Approvals: []yahtzee.User{"Alice"},
+	}
+	ts.Require().NoError(s.SaveUndo("ddddd", saved))
+
+	if got, err := s.LoadUndo("ddddd"); ts.NoError(err) {
+		ts.Exactly(saved.User, got.User)
+		ts.Exactly(saved.Requested, got.Requested)
+		ts.Exactly(saved.Approvals, got.Approvals)
+		ts.Exactly(saved.Game.Players, got.Game.Players)
+		ts.Exactly(saved.Game.Dices, got.Game.Dices)
+		ts.Exactly(saved.Game.Round, got.Game.Round)
+	}
+
+	ts.NoError(s.DeleteUndo("ddddd"))
+	_, err = s.LoadUndo("ddddd")
+	ts.Exactly(ErrNotExists, err)
+
+	ts.NoError(s.DeleteUndo("ddddd"))
+}
+
+func (ts *TestSuite) TestMoves() {
+	s := ts.Subject
+
+	got, err := s.LoadMoves("eeeee")
+	ts.NoError(err)
+	ts.Empty(got)
+
+	roll := yahtzee.Move{
+		User:      yahtzee.User("Alice"),
+		RollCount: 1,
+		Kept:      []int{},
+		Dices:     []int{1, 2, 3, 4, 5},
+	}
+	score := yahtzee.Move{
+		User:      yahtzee.User("Alice"),
+		RollCount: 1,
+		Dices:     []int{1, 2, 3, 4, 5},
+		Category:  yahtzee.Chance,
+		Sheet:     map[yahtzee.Category]int{yahtzee.Ones: 3},
+	}
+	ts.Require().NoError(s.AddMove("eeeee", roll))
+	ts.Require().NoError(s.AddMove("eeeee", score))
+
+	if got, err := s.LoadMoves("eeeee"); ts.NoError(err) {
+		ts.Exactly([]yahtzee.Move{roll, score}, got)
+	}
+
+	ts.NoError(s.RemoveLastMove("eeeee"))
+	if got, err := s.LoadMoves("eeeee"); ts.NoError(err) {
+		ts.Exactly([]yahtzee.Move{roll}, got)
 	}
 }
 
@@ -136,6 +222,8 @@ func (ts *TestSuite) newAdvancedGame() *yahtzee.Game {
 		Round:         5,
 		CurrentPlayer: 1,
 		RollCount:     1,
+		Seed:          42,
+		RollCounter:   23,
 		Scorer:        yahtzee.ComposeScorer(),
 		Context:       map[string]interface{}{},
 	}
@@ -0,0 +1,138 @@
+package yahtzee
+
+import "errors"
+
+var (
+	// ErrTeamRequired is returned when a user joins a team game without
+	// choosing a team.
+	ErrTeamRequired = errors.New("team required")
+
+	// ErrNoTeams is returned when a user joins a team in a game that is not
+	// played in teams.
+	ErrNoTeams = errors.New("game is not played in teams")
+)
+
+// Team is a group of players sharing a score sheet in the Teams mode.
+type Team struct {
+	// Name identifies the team
+	Name string
+
+	// Members has the users of the team in the order they take turns
+	Members []User
+
+	// ScoreSheet keeps the scores of the team
+	ScoreSheet map[Category]int
+
+	// Columns keeps the scores of the team when the game is played with
+	// multiple score sheet columns. The ScoreSheet is not used then.
+	Columns []map[Category]int `json:",omitempty"`
+}
+
+// NewTeam returns a new named team without members and with an empty score
+// sheet.
+func NewTeam(name string, columns int) *Team {
+	t := &Team{
+		Name:       name,
+		Members:    []User{},
+		ScoreSheet: map[Category]int{},
+	}
+	if columns > 1 {
+		t.Columns = make([]map[Category]int, columns)
+		for i := range t.Columns {
+			t.Columns[i] = map[Category]int{}
+		}
+	}
+	return t
+}
+
+// Sheet returns the score sheet in the `column`. Teams without columns have
+// only the ScoreSheet.
+func (t *Team) Sheet(column int) map[Category]int {
+	return sheetIn(t.ScoreSheet, t.Columns, column)
+}
+
+// Total returns the sum of the scores in the score sheet. With multiple
+// columns the sum of each column is multiplied by its position.
+func (t *Team) Total() int {
+	return totalOf(t.ScoreSheet, t.Columns)
+}
+
+// AddPlayerToTeam joins the user to the game in the named team. The team is
+// created when the user is the first to join it.
+func (g *Game) AddPlayerToTeam(u User, team string) error {
+	if !g.HasFeature(Teams) {
+		if team != "" {
+			return ErrNoTeams
+		}
+		return g.AddPlayer(u)
+	}
+	if team == "" {
+		return ErrTeamRequired
+	}
+
+	if err := g.addPlayer(u); err != nil {
+		return err
+	}
+
+	t := g.Team(team)
+	if t == nil {
+		t = NewTeam(team, g.NumberOfColumns())
+		g.Teams = append(g.Teams, t)
+	}
+	t.Members = append(t.Members, u)
+	g.Players[len(g.Players)-1].Team = team
+
+	return nil
+}
+
+// Team returns the team with the name, or nil when there is no such team.
+func (g *Game) Team(name string) *Team {
+	for _, t := range g.Teams {
+		if t.Name == name {
+			return t
+		}
+	}
+	return nil
+}
+
+// PlayerSheet returns the score sheet `column` the player scores into: the
+// sheet of the player's team in the Teams mode, the player's own otherwise.
+func (g *Game) PlayerSheet(p *Player, column int) map[Category]int {
+	if t := g.Team(p.Team); t != nil {
+		return t.Sheet(column)
+	}
+	return p.Sheet(column)
+}
+
+// PlayerTotal returns the total of the player, which is the total of the
+// player's team in the Teams mode.
+func (g *Game) PlayerTotal(p *Player) int {
+	if t := g.Team(p.Team); t != nil {
+		return t.Total()
+	}
+	return p.Total()
+}
+
+// nextTeamPlayer passes the turn to the next team, where the members take
+// turns in rotation from round to round.
+func (g *Game) nextTeamPlayer() {
+	current := 0
+	for i, t := range g.Teams {
+		if t.Name == g.Players[g.CurrentPlayer].Team {
+			current = i
+		}
+	}
+
+	next := (current + 1) % len(g.Teams)
+	if next == 0 {
+		g.Round++
+	}
+
+	members := g.Teams[next].Members
+	u := members[g.Round%len(members)]
+	for i, p := range g.Players {
+		if p.User == u {
+			g.CurrentPlayer = i
+		}
+	}
+}
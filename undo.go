@@ -0,0 +1,209 @@
+package yahtzee
+
+import "errors"
+
+var (
+	// ErrNothingToUndo is returned when the last action of the game can't be
+	// undone.
+	ErrNothingToUndo = errors.New("nothing to undo")
+
+	// ErrNotYourAction is returned when a user asks to undo another player's
+	// action.
+	ErrNotYourAction = errors.New("another players action")
+
+	// ErrUndoNotRequested is returned when an undo is approved or declined
+	// before the player asked for it.
+	ErrUndoNotRequested = errors.New("undo not requested")
+
+	// ErrNotOpponent is returned when the user approving or declining an undo
+	// is not an opponent of the player asking for it.
+	ErrNotOpponent = errors.New("not an opponent")
+)
+
+// Undo keeps the state of the game before its last lock or score, so the
+// action can be undone once every opponent approved.
+type Undo struct {
+	// Game is the state before the action
+	Game Game
+
+	// User made the action
+	User User
+
+	// Requested shows if the user asked to undo the action
+	Requested bool
+
+	// Approvals has the opponents who approved the undo
+	Approvals []User
+}
+
+// NewUndo returns the undo of the action the user is about to make on the
+// game.
+func NewUndo(g *Game, u User) *Undo {
+	return &Undo{
+		Game:      g.Copy(),
+		User:      u,
+		Approvals: []User{},
+	}
+}
+
+// Request asks the opponents to approve the undo.
+func (u *Undo) Request(user User) error {
+	if user != u.User {
+		return ErrNotYourAction
+	}
+	u.Requested = true
+	u.Approvals = []User{}
+	return nil
+}
+
+// Approve adds the approval of an opponent in the game.
+func (u *Undo) Approve(g *Game, user User) error {
+	if err := u.checkOpponent(g, user); err != nil {
+		return err
+	}
+	for _, a := range u.Approvals {
+		if a == user {
+			return nil
+		}
+	}
+	u.Approvals = append(u.Approvals, user)
+	return nil
+}
+
+// Decline checks if the opponent in the game can turn down the undo.
+func (u *Undo) Decline(g *Game, user User) error {
+	return u.checkOpponent(g, user)
+}
+
+// Approved tells if every opponent in the game approved the requested undo.
+// The bots approve every undo.
+func (u *Undo) Approved(g *Game) bool {
+	if !u.Requested {
+		return false
+	}
+	for _, p := range g.Players {
+		if p.User == u.User || p.Bot != "" {
+			continue
+		}
+		approved := false
+		for _, a := range u.Approvals {
+			if a == p.User {
+				approved = true
+			}
+		}
+		if !approved {
+			return false
+		}
+	}
+	return true
+}
+
+func (u *Undo) checkOpponent(g *Game, user User) error {
+	if !u.Requested {
+		return ErrUndoNotRequested
+	}
+	if user == u.User {
+		return ErrNotOpponent
+	}
+	for _, p := range g.Players {
+		if p.User == user {
+			return nil
+		}
+	}
+	return ErrNotOpponent
+}
+
+// Restore sets the game back to the state before the undone action. The
+// scorer, the roller and the context of the game are kept.
+func (g *Game) Restore(u *Undo) {
+	restored := u.Game.Copy()
+	restored.Scorer = g.Scorer
+	restored.Roller = g.Roller
+	restored.Context = g.Context
+	*g = restored
+}
+
+// Copy returns a copy of the game that doesn't share its players, teams and
+// dices.
+func (g *Game) Copy() Game {
+	res := *g
+
+	res.Players = make([]*Player, len(g.Players))
+	for i, p := range g.Players {
+		cp := *p
+		cp.ScoreSheet = copySheet(p.ScoreSheet)
+		if p.Columns != nil {
+			cp.Columns = make([]map[Category]int, len(p.Columns))
+			for j, c := range p.Columns {
+				cp.Columns[j] = copySheet(c)
+			}
+		}
+		if p.Hands != nil {
+			cp.Hands = append([]PokerHand{}, p.Hands...)
+		}
+		if p.Lines != nil {
+			cp.Lines = append([]int{}, p.Lines...)
+		}
+		if p.Dices != nil {
+			cp.Dices = copyDices(p.Dices)
+		}
+		if p.Handicap != nil {
+			h := *p.Handicap
+			cp.Handicap = &h
+		}
+		res.Players[i] = &cp
+	}
+
+	if g.Teams != nil {
+		res.Teams = make([]*Team, len(g.Teams))
+		for i, t := range g.Teams {
+			ct := *t
+			ct.Members = append([]User{}, t.Members...)
+			ct.ScoreSheet = copySheet(t.ScoreSheet)
+			if t.Columns != nil {
+				ct.Columns = make([]map[Category]int, len(t.Columns))
+				for j, c := range t.Columns {
+					ct.Columns[j] = copySheet(c)
+				}
+			}
+			res.Teams[i] = &ct
+		}
+	}
+
+	res.Dices = copyDices(g.Dices)
+
+	if g.Handicaps != nil {
+		res.Handicaps = make(map[User]Handicap, len(g.Handicaps))
+		for u, h := range g.Handicaps {
+			res.Handicaps[u] = h
+		}
+	}
+
+	res.Features = append([]Feature{}, g.Features...)
+	if g.Options != nil {
+		options := *g.Options
+		res.Options = &options
+	}
+
+	return res
+}
+
+func copyDices(dices []*Dice) []*Dice {
+	res := make([]*Dice, len(dices))
+	for i, d := range dices {
+		cd := *d
+		res[i] = &cd
+	}
+	return res
+}
+
+func copySheet(sheet map[Category]int) map[Category]int {
+	if sheet == nil {
+		return nil
+	}
+	res := make(map[Category]int, len(sheet))
+	for c, v := range sheet {
+		res[c] = v
+	}
+	return res
+}